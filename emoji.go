@@ -0,0 +1,20 @@
+package zvalidate
+
+import "unicode"
+
+// isEmoji reports if r is an emoji, or is used to form emoji sequences.
+func isEmoji(r rune) bool {
+	switch {
+	case r == 0xfe0f: // VARIATION SELECTOR-16; emoji presentation for the previous character.
+		return true
+	case r == 0x20e3: // COMBINING ENCLOSING KEYCAP; "1️⃣".
+		return true
+	case r >= 0x1f1e6 && r <= 0x1f1ff: // Regional indicators, used for flags.
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // Skin tone modifiers.
+		return true
+	case r >= 0xe0020 && r <= 0xe007f: // Tags, used for subdivision flags.
+		return true
+	}
+	return unicode.Is(emojiPresentation, r)
+}
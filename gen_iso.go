@@ -0,0 +1,120 @@
+//go:build ignore
+// +build ignore
+
+// Generate subdivision.go and iso639.go from the iso-codes JSON data:
+//
+//   go run gen_iso.go /usr/share/iso-codes/json
+//
+// The files are in the iso-codes package on most Linux systems, or can be
+// downloaded from https://salsa.debian.org/iso-codes-team/iso-codes
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: go run gen_iso.go path/to/iso-codes/json")
+		os.Exit(2)
+	}
+	dir := os.Args[1]
+
+	var subdiv struct {
+		Codes []struct {
+			Code string `json:"code"`
+		} `json:"3166-2"`
+	}
+	read(filepath.Join(dir, "iso_3166-2.json"), &subdiv)
+	subdivisions := make([]string, 0, len(subdiv.Codes))
+	for _, s := range subdiv.Codes {
+		subdivisions = append(subdivisions, strings.ToUpper(s.Code))
+	}
+	write("subdivision.go", "subdivisions", "ISO 3166-2 subdivision codes.",
+		"https://www.iso.org/iso-3166-country-codes.html", subdivisions,
+		func(a, b string) bool { return a[:2] == b[:2] })
+
+	var lang struct {
+		Codes []struct {
+			Alpha2        string `json:"alpha_2"`
+			Alpha3        string `json:"alpha_3"`
+			Bibliographic string `json:"bibliographic"`
+		} `json:"639-2"`
+	}
+	read(filepath.Join(dir, "iso_639-2.json"), &lang)
+	languages := make([]string, 0, len(lang.Codes)*2)
+	for _, l := range lang.Codes {
+		for _, c := range []string{l.Alpha2, l.Alpha3, l.Bibliographic} {
+			// Skip the "qaa-qtz" range reserved for local use.
+			if c != "" && !strings.Contains(c, "-") {
+				languages = append(languages, strings.ToLower(c))
+			}
+		}
+	}
+	write("iso639.go", "languageCodes", "ISO 639-1 and ISO 639-2 language codes.",
+		"https://www.loc.gov/standards/iso639-2/", languages,
+		func(a, b string) bool { return a[0] == b[0] })
+}
+
+func read(path string, dst interface{}) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		fatal(err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		fatal(fmt.Errorf("%s: %s", path, err))
+	}
+}
+
+// write a sorted list of codes as a Go file; a new line is started for every
+// code where sameLine() returns false, and lines are wrapped at about 100
+// columns.
+func write(file, varName, doc, link string, codes []string, sameLine func(a, b string) bool) {
+	if len(codes) == 0 {
+		fatal(fmt.Errorf("%s: no codes", file))
+	}
+	sort.Strings(codes)
+
+	b := new(bytes.Buffer)
+	b.WriteString("// Code generated by gen_iso.go; DO NOT EDIT.\n\n")
+	b.WriteString("package zvalidate\n\n")
+	fmt.Fprintf(b, "// %s\n//\n// %s\n", doc, link)
+	fmt.Fprintf(b, "var %s = []string{", varName)
+	var l int
+	for i, c := range codes {
+		if i > 0 && c == codes[i-1] {
+			continue
+		}
+		if i == 0 || !sameLine(c, codes[i-1]) || l+len(c)+4 > 100 {
+			b.WriteString("\n\t")
+			l = 8
+		} else {
+			b.WriteString(" ")
+			l++
+		}
+		fmt.Fprintf(b, "%q,", c)
+		l += len(c) + 3
+	}
+	b.WriteString("\n}\n")
+
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		fatal(err)
+	}
+	if err := ioutil.WriteFile(file, out, 0644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "gen_iso:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,317 @@
+//go:build ignore
+// +build ignore
+
+// Generate unicode_tables.go from the Unicode Character Database:
+//
+//   go run gen_unicode.go 14.0.0 /usr/share/unicode
+//
+// The files are in the unicode-data package on most Linux systems, or can be
+// downloaded from https://www.unicode.org/Public/14.0.0/ucd/ (UCD.zip).
+//
+// The version must match the version in the UCD files; update the go:generate
+// line in nfc.go when updating to a new version.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The "Symbols and Pictographs Extended-A" block; this is added to the
+// Emoji_Presentation table in its entirety as it contains only emoji.
+const pictographsExtA, pictographsExtAEnd = 0x1fa70, 0x1faff
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: go run gen_unicode.go version path/to/ucd")
+		os.Exit(2)
+	}
+	version, dir := os.Args[1], os.Args[2]
+
+	var (
+		ccc        = make(map[rune]uint8)
+		decomp     = make(map[rune][2]rune)
+		exclusions = make(map[rune]struct{})
+		gcb        = make(map[string][][2]rune)
+		emoji      = make(map[string][][2]rune)
+	)
+
+	// Fields: code point, name, category, combining class, bidi class,
+	// decomposition, ...
+	readFile(filepath.Join(dir, "UnicodeData.txt"), "", 15, func(f []string) {
+		r := parseRune(f[0])
+		c, err := strconv.ParseUint(f[3], 10, 8)
+		if err != nil {
+			fatal(fmt.Errorf("UnicodeData.txt: %s: %s", f[0], err))
+		}
+		if c != 0 {
+			ccc[r] = uint8(c)
+		}
+
+		// Compatibility decompositions start with a <tag>.
+		if f[5] == "" || strings.HasPrefix(f[5], "<") {
+			return
+		}
+		d := strings.Fields(f[5])
+		switch len(d) {
+		case 1:
+			decomp[r] = [2]rune{parseRune(d[0]), 0}
+		case 2:
+			decomp[r] = [2]rune{parseRune(d[0]), parseRune(d[1])}
+		default:
+			fatal(fmt.Errorf("UnicodeData.txt: %s: canonical decomposition with %d characters", f[0], len(d)))
+		}
+	})
+	readFile(filepath.Join(dir, "CompositionExclusions.txt"), version, 1, func(f []string) {
+		lo, hi := parseRange(f[0])
+		for r := lo; r <= hi; r++ {
+			exclusions[r] = struct{}{}
+		}
+	})
+	readFile(filepath.Join(dir, "auxiliary", "GraphemeBreakProperty.txt"), version, 2, func(f []string) {
+		lo, hi := parseRange(f[0])
+		gcb[f[1]] = append(gcb[f[1]], [2]rune{lo, hi})
+	})
+	readFile(filepath.Join(dir, "emoji", "emoji-data.txt"), "", 2, func(f []string) {
+		lo, hi := parseRange(f[0])
+		emoji[f[1]] = append(emoji[f[1]], [2]rune{lo, hi})
+	})
+
+	b := new(bytes.Buffer)
+	b.WriteString("// Code generated by gen_unicode.go; DO NOT EDIT.\n\n")
+	b.WriteString("package zvalidate\n\n")
+	b.WriteString("import \"unicode\"\n\n")
+	fmt.Fprintf(b, "// Tables from the Unicode %s character database.\n//\n", version)
+	fmt.Fprintf(b, "// https://www.unicode.org/Public/%s/ucd/\n\n", version)
+
+	writeCombining(b, ccc)
+	writeDecompose(b, decomp)
+	writeExclude(b, exclude(decomp, exclusions, ccc))
+
+	for _, p := range []string{"Prepend", "Control", "Extend", "SpacingMark", "L", "V", "T"} {
+		if len(gcb[p]) == 0 {
+			fatal(fmt.Errorf("GraphemeBreakProperty.txt: no %s", p))
+		}
+		fmt.Fprintf(b, "// Grapheme_Cluster_Break=%s\n", p)
+		writeRangeTable(b, "gcb"+p, gcb[p])
+	}
+	b.WriteString("// Extended_Pictographic=Yes\n")
+	writeRangeTable(b, "extendedPictographic", emoji["Extended_Pictographic"])
+
+	b.WriteString("// Characters with the Emoji_Presentation property, which are displayed as emoji\n" +
+		"// by default, and the entire \"Symbols and Pictographs Extended-A\" block, which\n" +
+		"// contains only emoji.\n" +
+		"//\n" +
+		"// The ZWJ (U+200D) used in emoji sequences such as \"👩‍💻\" isn't included as it's\n" +
+		"// also used in some scripts; all emoji sequences contain at least one of these\n" +
+		"// characters.\n")
+	writeRangeTable(b, "emojiPresentation", append(emoji["Emoji_Presentation"],
+		[2]rune{pictographsExtA, pictographsExtAEnd}))
+
+	out, err := format.Source(b.Bytes())
+	if err != nil {
+		fatal(err)
+	}
+	if err := ioutil.WriteFile("unicode_tables.go", out, 0644); err != nil {
+		fatal(err)
+	}
+}
+
+// readFile reads a semicolon-separated UCD file, calling fn for every line
+// with at least n fields. Comments and whitespace around fields are removed.
+//
+// If version isn't blank the first line must be "# Name-version.txt".
+func readFile(path, version string, n int, fn func([]string)) {
+	fp, err := os.Open(path)
+	if err != nil {
+		fatal(err)
+	}
+	defer fp.Close()
+
+	name := filepath.Base(path)
+	scan := bufio.NewScanner(fp)
+	for i := 0; scan.Scan(); i++ {
+		line := scan.Text()
+		if i == 0 && version != "" {
+			want := fmt.Sprintf("# %s-%s.txt", strings.TrimSuffix(name, ".txt"), version)
+			if strings.TrimSpace(line) != want {
+				fatal(fmt.Errorf("%s: wrong version: first line is %q; want %q", name, line, want))
+			}
+		}
+		if c := strings.IndexByte(line, '#'); c > -1 {
+			line = line[:c]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		f := strings.Split(line, ";")
+		if len(f) < n {
+			fatal(fmt.Errorf("%s: line %d: %d fields; want %d", name, i+1, len(f), n))
+		}
+		for j := range f {
+			f[j] = strings.TrimSpace(f[j])
+		}
+		fn(f)
+	}
+	if err := scan.Err(); err != nil {
+		fatal(err)
+	}
+}
+
+func parseRune(s string) rune {
+	r, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		fatal(err)
+	}
+	return rune(r)
+}
+
+// parseRange parses "0600" or "0600..0605".
+func parseRange(s string) (rune, rune) {
+	if i := strings.Index(s, ".."); i > -1 {
+		return parseRune(s[:i]), parseRune(s[i+2:])
+	}
+	r := parseRune(s)
+	return r, r
+}
+
+// exclude gets all characters with a two-character decomposition that have
+// Full_Composition_Exclusion: everything in CompositionExclusions.txt, and
+// "non-starter decompositions" that start with a combining mark or are a
+// combining mark themselves. Singletons are excluded by nfcCompose already.
+func exclude(decomp map[rune][2]rune, exclusions map[rune]struct{}, ccc map[rune]uint8) []rune {
+	var ex []rune
+	for r, d := range decomp {
+		if d[1] == 0 {
+			continue
+		}
+		if _, ok := exclusions[r]; ok || ccc[r] != 0 || ccc[d[0]] != 0 {
+			ex = append(ex, r)
+		}
+	}
+	sort.Slice(ex, func(i, j int) bool { return ex[i] < ex[j] })
+	return ex
+}
+
+func sortedKeys(m interface{}) []rune {
+	var keys []rune
+	switch mm := m.(type) {
+	case map[rune]uint8:
+		for r := range mm {
+			keys = append(keys, r)
+		}
+	case map[rune][2]rune:
+		for r := range mm {
+			keys = append(keys, r)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func writeCombining(b *bytes.Buffer, ccc map[rune]uint8) {
+	b.WriteString("// Canonical combining class for all characters with a class other than 0.\n")
+	b.WriteString("var nfcCombining = []struct {\n\tlo, hi rune\n\tccc    uint8\n}{\n")
+
+	keys := sortedKeys(ccc)
+	for i := 0; i < len(keys); {
+		lo, hi := keys[i], keys[i]
+		for i++; i < len(keys) && keys[i] == hi+1 && ccc[keys[i]] == ccc[lo]; i++ {
+			hi = keys[i]
+		}
+		fmt.Fprintf(b, "\t{0x%04x, 0x%04x, %d},\n", lo, hi, ccc[lo])
+	}
+	b.WriteString("}\n\n")
+}
+
+func writeDecompose(b *bytes.Buffer, decomp map[rune][2]rune) {
+	b.WriteString("// Canonical decompositions, except Hangul syllables; single characters have\n")
+	b.WriteString("// 0 as the second rune.\n")
+	b.WriteString("var nfcDecompose = map[rune][2]rune{\n")
+	for _, r := range sortedKeys(decomp) {
+		fmt.Fprintf(b, "\t0x%04x: {0x%04x, 0x%04x},\n", r, decomp[r][0], decomp[r][1])
+	}
+	b.WriteString("}\n\n")
+}
+
+func writeExclude(b *bytes.Buffer, ex []rune) {
+	b.WriteString("// Characters with a two-character decomposition that are excluded from\n")
+	b.WriteString("// composition.\n")
+	b.WriteString("var nfcExclude = map[rune]struct{}{")
+	for i, r := range ex {
+		if i%6 == 0 {
+			b.WriteString("\n\t")
+		} else {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(b, "0x%04x: {},", r)
+	}
+	b.WriteString("\n}\n\n")
+}
+
+// writeRangeTable writes the ranges as a *unicode.RangeTable, merging adjacent
+// and overlapping ranges.
+func writeRangeTable(b *bytes.Buffer, varName string, ranges [][2]rune) {
+	if len(ranges) == 0 {
+		fatal(fmt.Errorf("%s: no ranges", varName))
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := [][2]rune{ranges[0]}
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r[0] <= last[1]+1 {
+			if r[1] > last[1] {
+				last[1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	var r16, r32 [][2]rune
+	for _, r := range merged {
+		switch {
+		case r[1] <= 0xffff:
+			r16 = append(r16, r)
+		case r[0] > 0xffff:
+			r32 = append(r32, r)
+		default:
+			r16 = append(r16, [2]rune{r[0], 0xffff})
+			r32 = append(r32, [2]rune{0x10000, r[1]})
+		}
+	}
+
+	fmt.Fprintf(b, "var %s = &unicode.RangeTable{\n", varName)
+	latin := 0
+	for i, list := range [][][2]rune{r16, r32} {
+		if len(list) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "\t%s: []unicode.%s{\n", []string{"R16", "R32"}[i], []string{"Range16", "Range32"}[i])
+		for _, r := range list {
+			fmt.Fprintf(b, "\t\t{0x%04x, 0x%04x, 1},\n", r[0], r[1])
+			if i == 0 && r[1] <= 0xff {
+				latin++
+			}
+		}
+		b.WriteString("\t},\n")
+	}
+	if latin > 0 {
+		fmt.Fprintf(b, "\tLatinOffset: %d,\n", latin)
+	}
+	b.WriteString("}\n\n")
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "gen_unicode:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,120 @@
+package zvalidate
+
+import "unicode"
+
+// Grapheme cluster break properties, from UAX #29.
+const (
+	gbOther = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+)
+
+// graphemeCount gets the number of extended grapheme clusters in s, as
+// described in UAX #29: "👩‍👩‍👧‍👦" and "🇳🇱" are one grapheme, as is "é" written
+// as "e" followed by a combining accent.
+//
+// Invalid UTF-8 is counted as one grapheme per byte.
+func graphemeCount(s string) int {
+	var (
+		n        int
+		prev     = -1
+		ri       int  // Number of preceding regional indicators.
+		pict     bool // Preceded by Extended_Pictographic Extend*
+		pictZWJ  bool // Preceded by Extended_Pictographic Extend* ZWJ
+		isPict   bool
+		boundary bool
+	)
+	for _, r := range s {
+		c := graphemeBreak(r)
+		isPict = unicode.Is(extendedPictographic, r)
+
+		switch {
+		case prev == -1:
+			boundary = true
+		case prev == gbCR && c == gbLF: // GB3
+			boundary = false
+		case prev == gbCR || prev == gbLF || prev == gbControl: // GB4
+			boundary = true
+		case c == gbCR || c == gbLF || c == gbControl: // GB5
+			boundary = true
+		case prev == gbL && (c == gbL || c == gbV || c == gbLV || c == gbLVT): // GB6
+			boundary = false
+		case (prev == gbLV || prev == gbV) && (c == gbV || c == gbT): // GB7
+			boundary = false
+		case (prev == gbLVT || prev == gbT) && c == gbT: // GB8
+			boundary = false
+		case c == gbExtend || c == gbZWJ || c == gbSpacingMark: // GB9, GB9a
+			boundary = false
+		case prev == gbPrepend: // GB9b
+			boundary = false
+		case prev == gbZWJ && pictZWJ && isPict: // GB11
+			boundary = false
+		case prev == gbRegionalIndicator && c == gbRegionalIndicator: // GB12, GB13
+			boundary = ri%2 == 0
+		default: // GB999
+			boundary = true
+		}
+		if boundary {
+			n++
+		}
+
+		if c == gbRegionalIndicator {
+			ri++
+		} else {
+			ri = 0
+		}
+		pictZWJ = pict && c == gbZWJ
+		pict = isPict || (pict && c == gbExtend)
+		prev = c
+	}
+	return n
+}
+
+// graphemeBreak gets the Grapheme_Cluster_Break property for r. CR, LF, ZWJ,
+// Regional_Indicator, LV, and LVT are handled here; the rest is looked up in
+// the tables in unicode_tables.go.
+func graphemeBreak(r rune) int {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r == 0x200d:
+		return gbZWJ
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return gbRegionalIndicator
+	case r < 0x300 && r >= 0x20 && r != 0x7f && (r < 0x80 || r > 0x9f) && r != 0xad:
+		return gbOther // Fast path for common Latin text.
+	case r >= 0xac00 && r <= 0xd7a3: // Hangul syllables.
+		if (r-0xac00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case unicode.Is(gcbControl, r):
+		return gbControl
+	case unicode.Is(gcbExtend, r):
+		return gbExtend
+	case unicode.Is(gcbSpacingMark, r):
+		return gbSpacingMark
+	case unicode.Is(gcbPrepend, r):
+		return gbPrepend
+	case unicode.Is(gcbL, r):
+		return gbL
+	case unicode.Is(gcbV, r):
+		return gbV
+	case unicode.Is(gcbT, r):
+		return gbT
+	}
+	return gbOther
+}
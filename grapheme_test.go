@@ -0,0 +1,43 @@
+package zvalidate
+
+import (
+	"testing"
+)
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"a", 1},
+		{"abc", 3},
+		{"\r\n", 1},
+		{"\n\r", 2},
+		{"\x00a", 2},
+		{"\xff\xfe", 2},
+		{"é", 1},
+		{"👩‍👩‍👧‍👦", 1},
+		{"👍🏽", 1},
+		{"🏳️‍🌈", 1},
+		{"1️⃣", 1},
+		{"©️", 1},
+		{"🇳🇱🇬🇧", 2},
+		{"🇳🇱🇬", 2},
+		{"a‍b", 2},
+		{"한국어", 3},
+		{"각", 1},
+		{"ราคาเหนือจอง", 11},
+		{"؀a", 1},
+		{"aः", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := graphemeCount(tt.in)
+			if got != tt.want {
+				t.Errorf("graphemeCount(%q) = %d; want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
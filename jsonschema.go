@@ -0,0 +1,389 @@
+package zvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// JSONSchema validates the value against a JSON schema.
+//
+// The value can be anything that can be encoded to JSON, such as a struct or
+// map[string]interface{}, or a json.RawMessage with the JSON document.
+//
+// Errors are added with key as the prefix; for example if the schema has a
+// "city" property inside an "address" object, errors will be added as
+// "key.address.city". Array items are added as "key[0]", "key[1]", etc.
+//
+// Not all of JSON schema is supported; the supported keywords are:
+//
+//   type, enum, const
+//   minLength, maxLength, pattern, format
+//   minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//   properties, required, additionalProperties
+//   items, minItems, maxItems, uniqueItems
+//   allOf, anyOf, oneOf, not
+//
+// The supported formats are "email", "uri", "hostname", "ipv4", "ipv6",
+// "date-time", and "date"; other formats are ignored. Any other keywords such as
+// "$ref" are also ignored.
+//
+// This will panic if the schema is not valid JSON, or if value can't be encoded
+// as JSON.
+func (v *Validator) JSONSchema(key string, value interface{}, schema []byte, message ...string) {
+	var s interface{}
+	err := json.Unmarshal(schema, &s)
+	if err != nil {
+		panic(fmt.Sprintf("zvalidate.JSONSchema: invalid schema: %s", err))
+	}
+
+	j, ok := value.(json.RawMessage)
+	if !ok {
+		j, err = json.Marshal(value)
+		if err != nil {
+			panic(fmt.Sprintf("zvalidate.JSONSchema: %s", err))
+		}
+	}
+	d := json.NewDecoder(bytes.NewReader(j))
+	d.UseNumber()
+	var doc interface{}
+	err = d.Decode(&doc)
+	if err != nil {
+		panic(fmt.Sprintf("zvalidate.JSONSchema: %s", err))
+	}
+
+	errs := New(WithMessages(v.Messages))
+	errs.jsonSchema(key, doc, s)
+	if !errs.HasErrors() {
+		return
+	}
+
+	if msg := getMessage(message, ""); msg != "" {
+		v.AppendCode(key, "schema", msg)
+		return
+	}
+	v.Merge(errs)
+}
+
+func (v *Validator) jsonSchema(key string, doc, schema interface{}) {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		// "true" accepts everything, "false" accepts nothing.
+		if b, ok := schema.(bool); ok && !b {
+			v.appendMessage(key, "schema", nil)
+		}
+		return
+	}
+
+	if t, ok := s["type"]; ok && !schemaType(doc, t) {
+		v.appendMessage(key, "schema_type", nil, schemaList(t, " or "))
+		return
+	}
+	if c, ok := s["const"]; ok && !jsonEqual(doc, c) {
+		v.appendParams(key, "include", map[string]interface{}{"values": []interface{}{c}}, nil, schemaList(c, ", "))
+	}
+	if e, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, ee := range e {
+			if jsonEqual(doc, ee) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.appendParams(key, "include", map[string]interface{}{"values": e}, nil, schemaList(e, ", "))
+		}
+	}
+
+	switch d := doc.(type) {
+	case string:
+		v.jsonSchemaString(key, d, s)
+	case json.Number:
+		v.jsonSchemaNumber(key, d, s)
+	case []interface{}:
+		v.jsonSchemaArray(key, d, s)
+	case map[string]interface{}:
+		v.jsonSchemaObject(key, d, s)
+	}
+
+	if all, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			v.jsonSchema(key, doc, sub)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok && schemaMatches(doc, anyOf) == 0 {
+		v.appendMessage(key, "schema", nil)
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok && schemaMatches(doc, oneOf) != 1 {
+		v.appendMessage(key, "schema", nil)
+	}
+	if not, ok := s["not"]; ok && schemaMatches(doc, []interface{}{not}) == 1 {
+		v.appendMessage(key, "schema", nil)
+	}
+}
+
+func (v *Validator) jsonSchemaString(key, doc string, s map[string]interface{}) {
+	l := utf8.RuneCountInString(doc)
+	if n, ok := schemaInt(s, "minLength"); ok && l < n {
+		v.appendParams(key, "len_longer", map[string]interface{}{"min": n}, nil, n)
+	}
+	if n, ok := schemaInt(s, "maxLength"); ok && l > n {
+		v.appendParams(key, "len_shorter", map[string]interface{}{"max": n}, nil, n)
+	}
+	if p, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			panic(fmt.Sprintf("zvalidate.JSONSchema: invalid pattern: %s", err))
+		}
+		if !re.MatchString(doc) {
+			v.appendParams(key, "schema_pattern", map[string]interface{}{"pattern": p}, nil, p)
+		}
+	}
+
+	if doc == "" {
+		return
+	}
+	switch s["format"] {
+	case "email":
+		v.Email(key, doc)
+	case "uri":
+		v.URLLocal(key, doc)
+	case "hostname":
+		v.Hostname(key, doc)
+	case "ipv4":
+		v.IPv4(key, doc)
+	case "ipv6":
+		v.IPv6(key, doc)
+	case "date-time":
+		v.Date(key, doc, time.RFC3339)
+	case "date":
+		v.Date(key, doc, "2006-01-02")
+	}
+}
+
+func (v *Validator) jsonSchemaNumber(key string, doc json.Number, s map[string]interface{}) {
+	n, _ := doc.Float64()
+	if m, ok := schemaFloat(s, "minimum"); ok && n < m {
+		v.appendParams(key, "number_higher", map[string]interface{}{"min": m}, nil, formatFloat(m))
+	}
+	if m, ok := schemaFloat(s, "maximum"); ok && n > m {
+		v.appendParams(key, "number_lower", map[string]interface{}{"max": m}, nil, formatFloat(m))
+	}
+	if m, ok := schemaFloat(s, "exclusiveMinimum"); ok && n <= m {
+		v.appendParams(key, "number_above", map[string]interface{}{"min": m}, nil, formatFloat(m))
+	}
+	if m, ok := schemaFloat(s, "exclusiveMaximum"); ok && n >= m {
+		v.appendParams(key, "number_below", map[string]interface{}{"max": m}, nil, formatFloat(m))
+	}
+	if m, ok := schemaFloat(s, "multipleOf"); ok && m > 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.appendParams(key, "multiple_of", map[string]interface{}{"n": m}, nil, formatFloat(m))
+		}
+	}
+}
+
+func (v *Validator) jsonSchemaArray(key string, doc []interface{}, s map[string]interface{}) {
+	if n, ok := schemaInt(s, "minItems"); ok && len(doc) < n {
+		v.appendParams(key, "slice_len_min", map[string]interface{}{"min": n}, nil, n)
+	}
+	if n, ok := schemaInt(s, "maxItems"); ok && len(doc) > n {
+		v.appendParams(key, "slice_len_max", map[string]interface{}{"max": n}, nil, n)
+	}
+	if u, _ := s["uniqueItems"].(bool); u {
+	outer:
+		for i := range doc {
+			for j := 0; j < i; j++ {
+				if jsonEqual(doc[i], doc[j]) {
+					v.appendMessage(fmt.Sprintf("%s[%d]", key, i), "unique", nil, schemaList(doc[i], ""))
+					break outer
+				}
+			}
+		}
+	}
+	if items, ok := s["items"]; ok {
+		for i := range doc {
+			v.jsonSchema(fmt.Sprintf("%s[%d]", key, i), doc[i], items)
+		}
+	}
+}
+
+func (v *Validator) jsonSchemaObject(key string, doc map[string]interface{}, s map[string]interface{}) {
+	props, _ := s["properties"].(map[string]interface{})
+
+	if req, ok := s["required"].([]interface{}); ok {
+		for _, r := range req {
+			if r, ok := r.(string); ok {
+				if _, ok := doc[r]; !ok {
+					v.appendMessage(schemaKey(key, r), "required", nil)
+				}
+			}
+		}
+	}
+
+	// Sort to make sure the order of errors is always the same.
+	names := make([]string, 0, len(doc))
+	for k := range doc {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		if p, ok := props[k]; ok {
+			v.jsonSchema(schemaKey(key, k), doc[k], p)
+			continue
+		}
+		if a, ok := s["additionalProperties"]; ok {
+			if b, ok := a.(bool); ok && !b {
+				v.appendMessage(schemaKey(key, k), "schema_additional", nil)
+			} else {
+				v.jsonSchema(schemaKey(key, k), doc[k], a)
+			}
+		}
+	}
+}
+
+// schemaMatches gets the number of schemas that doc matches.
+func schemaMatches(doc interface{}, schemas []interface{}) int {
+	n := 0
+	for _, s := range schemas {
+		v := New()
+		v.jsonSchema("", doc, s)
+		if !v.HasErrors() {
+			n++
+		}
+	}
+	return n
+}
+
+func schemaType(doc, typ interface{}) bool {
+	if l, ok := typ.([]interface{}); ok {
+		for _, t := range l {
+			if schemaType(doc, t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch typ {
+	case "null":
+		return doc == nil
+	case "boolean":
+		_, ok := doc.(bool)
+		return ok
+	case "string":
+		_, ok := doc.(string)
+		return ok
+	case "number":
+		_, ok := doc.(json.Number)
+		return ok
+	case "integer":
+		n, ok := doc.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	case "array":
+		_, ok := doc.([]interface{})
+		return ok
+	case "object":
+		_, ok := doc.(map[string]interface{})
+		return ok
+	}
+	return true
+}
+
+func schemaKey(key, prop string) string {
+	if key == "" {
+		return prop
+	}
+	return key + "." + prop
+}
+
+func schemaInt(s map[string]interface{}, k string) (int, bool) {
+	f, ok := schemaFloat(s, k)
+	return int(f), ok
+}
+
+func schemaFloat(s map[string]interface{}, k string) (float64, bool) {
+	n, ok := s[k].(float64)
+	return n, ok
+}
+
+// schemaList formats a value or list of values for display in errors.
+func schemaList(v interface{}, sep string) string {
+	l, ok := v.([]interface{})
+	if !ok {
+		l = []interface{}{v}
+	}
+
+	s := make([]string, len(l))
+	for i := range l {
+		if str, ok := l[i].(string); ok {
+			s[i] = str
+		} else {
+			j, _ := json.Marshal(l[i])
+			s[i] = string(j)
+		}
+	}
+	return strings.Join(s, sep)
+}
+
+// jsonEqual reports if two decoded JSON values are equal; numbers are compared
+// by their value, so 1 and 1.0 are equal.
+func jsonEqual(a, b interface{}) bool {
+	switch aa := a.(type) {
+	case json.Number, float64:
+		return toFloat(a) == toFloat(b)
+	case []interface{}:
+		bb, ok := b.([]interface{})
+		if !ok || len(aa) != len(bb) {
+			return false
+		}
+		for i := range aa {
+			if !jsonEqual(aa[i], bb[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bb, ok := b.(map[string]interface{})
+		if !ok || len(aa) != len(bb) {
+			return false
+		}
+		for k := range aa {
+			if _, ok := bb[k]; !ok || !jsonEqual(aa[k], bb[k]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func toFloat(v interface{}) float64 {
+	switch vv := v.(type) {
+	case json.Number:
+		f, _ := vv.Float64()
+		return f
+	case float64:
+		return vv
+	}
+	return math.NaN()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
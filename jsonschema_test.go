@@ -0,0 +1,138 @@
+package zvalidate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["name", "email"],
+		"additionalProperties": false,
+		"properties": {
+			"name":    {"type": "string", "minLength": 2, "maxLength": 10},
+			"email":   {"type": "string", "format": "email"},
+			"age":     {"type": "integer", "minimum": 18, "maximum": 150},
+			"price":   {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.25},
+			"status":  {"enum": ["active", "inactive", 1]},
+			"kind":    {"const": "user"},
+			"code":    {"type": "string", "pattern": "^[A-Z]{3}$"},
+			"note":    {"type": ["string", "null"]},
+			"address": {
+				"type": "object",
+				"required": ["city"],
+				"properties": {"city": {"type": "string"}}
+			},
+			"tags": {
+				"type": "array",
+				"minItems": 1,
+				"maxItems": 3,
+				"uniqueItems": true,
+				"items": {"type": "string", "minLength": 1}
+			},
+			"id":   {"anyOf": [{"type": "integer"}, {"type": "string", "pattern": "^[a-f0-9]+$"}]},
+			"one":  {"oneOf": [{"type": "integer"}, {"type": "number"}]},
+			"not":  {"not": {"type": "string"}}
+		}
+	}`)
+
+	tests := []struct {
+		in   interface{}
+		want map[string][]string
+	}{
+		{
+			map[string]interface{}{"name": "Martin", "email": "martin@example.com"},
+			map[string][]string{},
+		},
+		{
+			json.RawMessage(`{
+				"name": "Martin", "email": "martin@example.com", "age": 18, "price": 1.25,
+				"status": 1, "kind": "user", "code": "ABC", "note": null,
+				"address": {"city": "Bristol"}, "tags": ["a", "b"],
+				"id": "abc123", "one": 1.5, "not": 5
+			}`),
+			map[string][]string{},
+		},
+		{
+			struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+			}{"Martin", "martin@example.com"},
+			map[string][]string{},
+		},
+		{
+			json.RawMessage(`"not an object"`),
+			map[string][]string{"k": {"must be of type object"}},
+		},
+		{
+			map[string]interface{}{},
+			map[string][]string{"k.name": {"must be set"}, "k.email": {"must be set"}},
+		},
+		{
+			json.RawMessage(`{
+				"name": "M", "email": "martin", "age": 17.5, "price": 0.3,
+				"status": "x", "kind": "admin", "code": "abc", "note": 1,
+				"address": {}, "tags": ["a", "", "a", "b"], "id": "xyz",
+				"one": 1, "not": "str", "unknown": true
+			}`),
+			map[string][]string{
+				"k.name":         {"must be longer than 2 characters"},
+				"k.email":        {"must be a valid email address"},
+				"k.age":          {"must be of type integer"},
+				"k.price":        {"must be a multiple of 0.25"},
+				"k.status":       {`must be one of ‘active, inactive, 1’`},
+				"k.kind":         {"must be one of ‘user’"},
+				"k.code":         {"must match ‘^[A-Z]{3}$’"},
+				"k.note":         {"must be of type string or null"},
+				"k.address.city": {"must be set"},
+				"k.tags":         {"must have at most 3 items"},
+				"k.tags[1]":      {"must be longer than 1 characters"},
+				"k.tags[2]":      {"duplicate value ‘a’"},
+				"k.id":           {"must match the schema"},
+				"k.one":          {"must match the schema"},
+				"k.not":          {"must match the schema"},
+				"k.unknown":      {"is not allowed"},
+			},
+		},
+		{
+			json.RawMessage(`{"name": "Martin", "email": "martin@example.com", "age": 200, "price": 0}`),
+			map[string][]string{
+				"k.age":   {"must be 150 or lower"},
+				"k.price": {"must be higher than 0"},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			v := New()
+			v.JSONSchema("k", tt.in, schema)
+
+			if !reflect.DeepEqual(v.Errors, tt.want) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.want)
+			}
+		})
+	}
+
+	t.Run("message", func(t *testing.T) {
+		v := New()
+		v.JSONSchema("k", json.RawMessage(`{}`), schema, "foo")
+		want := map[string][]string{"k": {"foo"}}
+		if !reflect.DeepEqual(v.Errors, want) {
+			t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, want)
+		}
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("no panic")
+			}
+		}()
+		v := New()
+		v.JSONSchema("k", "", []byte(`{`))
+	})
+}
@@ -0,0 +1,153 @@
+package zvalidate
+
+import (
+	"errors"
+	"strings"
+)
+
+// Irregular grandfathered tags, which don't follow the normal syntax.
+var bcp47Grandfathered = map[string]string{
+	"en-gb-oed":   "en-GB-oed",
+	"i-ami":       "i-ami",
+	"i-bnn":       "i-bnn",
+	"i-default":   "i-default",
+	"i-enochian":  "i-enochian",
+	"i-hak":       "i-hak",
+	"i-klingon":   "i-klingon",
+	"i-lux":       "i-lux",
+	"i-mingo":     "i-mingo",
+	"i-navajo":    "i-navajo",
+	"i-pwn":       "i-pwn",
+	"i-tao":       "i-tao",
+	"i-tay":       "i-tay",
+	"i-tsu":       "i-tsu",
+	"sgn-be-fr":   "sgn-BE-FR",
+	"sgn-be-nl":   "sgn-BE-NL",
+	"sgn-ch-de":   "sgn-CH-DE",
+	"art-lojban":  "art-lojban",
+	"cel-gaulish": "cel-gaulish",
+	"zh-guoyu":    "zh-guoyu",
+	"zh-hakka":    "zh-hakka",
+	"zh-xiang":    "zh-xiang",
+}
+
+// checkBCP47Syntax checks if tag is a well-formed BCP 47 language tag and
+// returns it with the canonical separator and casing.
+//
+// This only checks the syntax from RFC 5646, section 2.1; it doesn't look at
+// the IANA registry, so unknown subtags are accepted and deprecated ones aren't
+// replaced ("iw" stays "iw", rather than becoming "he" as x/text/language
+// would do).
+func checkBCP47Syntax(tag string) (string, error) {
+	tag = strings.ToLower(strings.Replace(tag, "_", "-", -1))
+	if g, ok := bcp47Grandfathered[tag]; ok {
+		return g, nil
+	}
+
+	parts := strings.Split(tag, "-")
+	for _, p := range parts {
+		if len(p) < 1 || len(p) > 8 || !isAlphaNum(p) {
+			return "", errors.New("invalid subtag")
+		}
+	}
+
+	// Private use tag only: "x-whatever".
+	if parts[0] == "x" {
+		if len(parts) < 2 {
+			return "", errors.New("empty private use subtag")
+		}
+		return tag, nil
+	}
+
+	// Language: 2-3 letters followed by up to three 3-letter extended language
+	// subtags, or 4-8 letters.
+	if len(parts[0]) < 2 || !isAlpha(parts[0]) {
+		return "", errors.New("invalid language subtag")
+	}
+	i := 1
+	if len(parts[0]) <= 3 {
+		for n := 0; n < 3 && i < len(parts) && len(parts[i]) == 3 && isAlpha(parts[i]); n++ {
+			i++
+		}
+	}
+
+	// Script: 4 letters.
+	if i < len(parts) && len(parts[i]) == 4 && isAlpha(parts[i]) {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		i++
+	}
+
+	// Region: 2 letters or 3 digits.
+	if i < len(parts) && ((len(parts[i]) == 2 && isAlpha(parts[i])) || (len(parts[i]) == 3 && isDigit(parts[i]))) {
+		parts[i] = strings.ToUpper(parts[i])
+		i++
+	}
+
+	// Variants: 5-8 characters, or a digit followed by 3 characters.
+	seen := make(map[string]struct{})
+	for i < len(parts) && (len(parts[i]) >= 5 || (len(parts[i]) == 4 && isDigit(parts[i][:1]))) {
+		if _, ok := seen[parts[i]]; ok {
+			return "", errors.New("duplicate variant subtag")
+		}
+		seen[parts[i]] = struct{}{}
+		i++
+	}
+
+	// Extensions: a single character other than "x" followed by one or more
+	// subtags of 2-8 characters.
+	for i < len(parts) && len(parts[i]) == 1 && parts[i] != "x" {
+		if _, ok := seen[parts[i]]; ok {
+			return "", errors.New("duplicate extension")
+		}
+		seen[parts[i]] = struct{}{}
+		i++
+
+		n := 0
+		for ; i < len(parts) && len(parts[i]) >= 2; i++ {
+			n++
+		}
+		if n == 0 {
+			return "", errors.New("empty extension")
+		}
+	}
+
+	// Private use: "x" followed by one or more subtags.
+	if i < len(parts) && parts[i] == "x" {
+		if i == len(parts)-1 {
+			return "", errors.New("empty private use subtag")
+		}
+		i = len(parts)
+	}
+
+	if i != len(parts) {
+		return "", errors.New("invalid subtag")
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+func isAlpha(s string) bool {
+	for _, c := range s {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphaNum(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
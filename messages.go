@@ -1,29 +1,423 @@
 package zvalidate
 
-// Messages for the validations; this can be changed for i18n.
+import "fmt"
+
+// Messages for the validations.
+//
+// These are the defaults for all Validators; use Validator.Messages to change
+// them for one Validator, for example to translate them.
 var (
-	MessageRequired    = "must be set"
-	MessageDomain      = "must be a valid domain"
-	MessageHostname    = "must be a valid hostname"
-	MessageURL         = "must be a valid url"
-	MessageEmail       = "must be a valid email address"
-	MessageIPv4        = "must be a valid IPv4 address"
-	MessageIP          = "must be a valid IPv4 or IPv6 address"
-	MessageHexColor    = "must be a valid color code"
-	MessageLenLonger   = "must be longer than %d characters"
-	MessageLenShorter  = "must be shorter than %d characters"
-	MessageExclude     = "cannot be ‘%s’"
-	MessageInclude     = "must be one of ‘%s’"
-	MessageInteger     = "must be a whole number"
-	MessageBool        = "must be a boolean"
-	MessageDate        = "must be a date as ‘%s’"
-	MessagePhone       = "must be a valid phone number"
-	MessageRangeHigher = "must be %d or higher"
-	MessageRangeLower  = "must be %d or lower"
-	MessageUTF8        = "must be UTF-8"
-	MessageContains    = "cannot contain the characters %s"
+	MessageRequired         = "must be set"
+	MessageRequireAny       = "at least one must be set"
+	MessageDomain           = "must be a valid domain"
+	MessageHostname         = "must be a valid hostname"
+	MessageHost             = "must be a valid hostname or IP address"
+	MessageHostPort         = "must be a valid host and port, such as ‘example.com:8080’"
+	MessageURL              = "must be a valid url"
+	MessageURLPath          = "must be a valid url with a path"
+	MessageURLNoIP          = "cannot be an IP address"
+	MessageURLScheme        = "must include %s"
+	MessageQueryString      = "must be valid URL parameters"
+	MessageQueryStringKeys  = "must have at most %d parameters"
+	MessageFileExt          = "must have one of the extensions ‘%s’"
+	MessageCSVMax           = "must have at most %d items"
+	MessageEmail            = "must be a valid email address"
+	MessageIPv4             = "must be a valid IPv4 address"
+	MessageIPv6             = "must be a valid IPv6 address"
+	MessageIP               = "must be a valid IPv4 or IPv6 address"
+	MessageIPIn             = "must be in one of the networks ‘%s’"
+	MessagePublicIP         = "must be a public IP address"
+	MessageHexColor         = "must be a valid color code"
+	MessageColorFunc        = "must be a valid color"
+	MessageColorRange       = "%s component must be %s"
+	MessageLenLonger        = "must be longer than %d characters"
+	MessageLenShorter       = "must be shorter than %d characters"
+	MessageWordsLonger      = "must be longer than %d words"
+	MessageWordsShorter     = "must be shorter than %d words"
+	MessageSliceLenMin      = "must have at least %d items"
+	MessageSliceLenMax      = "must have at most %d items"
+	MessageExclude          = "cannot be ‘%s’"
+	MessageInclude          = "must be one of ‘%s’"
+	MessageNotEqual         = "must be different"
+	MessageInteger          = "must be a whole number"
+	MessageNumeric          = "must contain only digits"
+	MessageOTP              = "must be a %d-digit code"
+	MessagePIN              = "must be %d digits"
+	MessagePINRepeated      = "cannot be the same digit repeated"
+	MessagePINSequence      = "cannot be a sequence of digits"
+	MessagePINCommon        = "is too common"
+	MessageAlpha            = "must contain only letters"
+	MessageAlphanumeric     = "must contain only letters and digits"
+	MessageSingleLine       = "must be a single line"
+	MessageMaxLines         = "must be at most %d lines"
+	MessageBool             = "must be a boolean"
+	MessageDate             = "must be a date as ‘%s’"
+	MessageTimestamp        = "must be a RFC 3339 timestamp"
+	MessageDateOrder        = "cannot be before the start date"
+	MessagePhone            = "must be a valid phone number"
+	MessageRangeHigher      = "must be %d or higher"
+	MessageRangeLower       = "must be %d or lower"
+	MessageNumberHigher     = "must be %s or higher"
+	MessageNumberLower      = "must be %s or lower"
+	MessageNumberAbove      = "must be higher than %s"
+	MessageNumberBelow      = "must be lower than %s"
+	MessageMultipleOf       = "must be a multiple of %s"
+	MessagePositive         = "must be a positive number"
+	MessageNegative         = "must be a negative number"
+	MessageNotZero          = "must not be zero"
+	MessagePercent          = "must be a percentage between 0 and 100"
+	MessageUTF8             = "must be UTF-8"
+	MessageNFC              = "must be in Unicode NFC form"
+	MessageContains         = "cannot contain the characters %s"
+	MessageNoEmoji          = "must not contain emoji"
+	MessagePrintable        = "cannot contain control or invisible characters"
+	MessageNoSpace          = "cannot start or end with whitespace"
+	MessagePasswordHash     = "must be a supported password hash"
+	MessageSafePath         = "must be a relative path"
+	MessageSafePathDepth    = "cannot be more than %d levels deep"
+	MessageFilePath         = "must be a valid file path"
+	MessageCountryCode      = "must be a valid country code"
+	MessageSubdivision      = "must be a valid subdivision code"
+	MessageCurrency         = "must be a valid currency code"
+	MessageMoney            = "must be a valid amount"
+	MessageMoneyDecimals    = "must have at most %d decimal places"
+	MessageMoneyNegative    = "cannot be negative"
+	MessageABARoutingNumber = "must be a valid routing number"
+	MessageSortCode         = "must be a valid sort code"
+	MessageLanguage         = "must be a valid language tag"
+	MessageLocale           = "must be a valid locale"
+	MessageTimezone         = "must be a valid timezone"
+	MessageRegexp           = "must be a valid regular expression: %s"
+	MessageIdentifier       = "must be a valid identifier"
+	MessageUnique           = "duplicate value ‘%s’"
+	MessageSchema           = "must match the schema"
+	MessageSchemaType       = "must be of type %s"
+	MessageSchemaPattern    = "must match ‘%s’"
+	MessageSchemaAdditional = "is not allowed"
 )
 
+// Catalog is a set of messages for the validations, by message ID.
+//
+// The message ID is the name of the Message variable without "Message" in
+// snake_case: "required" for MessageRequired, "len_longer" for
+// MessageLenLonger, etc. Messages which aren't in the Catalog use the package
+// defaults. The message ID is also used as the code in Validator.Codes.
+//
+// Messages with parameters (such as "%d") must have the same parameters in the
+// same order.
+type Catalog map[string]string
+
+// Catalogs of translated messages, by language.
+//
+// This includes "en" and "nl"; you can add your own at init time:
+//
+//   func init() {
+//       zvalidate.Catalogs["de"] = zvalidate.Catalog{
+//           "required": "muss ausgefüllt sein",
+//       }
+//   }
+//
+// And use them with:
+//
+//   v := zvalidate.New()
+//   v.Messages = zvalidate.Catalogs["de"]
+var Catalogs = map[string]Catalog{
+	"en": {
+		"required":           "must be set",
+		"require_any":        "at least one must be set",
+		"domain":             "must be a valid domain",
+		"hostname":           "must be a valid hostname",
+		"host":               "must be a valid hostname or IP address",
+		"host_port":          "must be a valid host and port, such as ‘example.com:8080’",
+		"url":                "must be a valid url",
+		"url_path":           "must be a valid url with a path",
+		"url_no_ip":          "cannot be an IP address",
+		"url_scheme":         "must include %s",
+		"query_string":       "must be valid URL parameters",
+		"query_string_keys":  "must have at most %d parameters",
+		"file_ext":           "must have one of the extensions ‘%s’",
+		"csv_max":            "must have at most %d items",
+		"email":              "must be a valid email address",
+		"ipv4":               "must be a valid IPv4 address",
+		"ipv6":               "must be a valid IPv6 address",
+		"ip":                 "must be a valid IPv4 or IPv6 address",
+		"ip_in":              "must be in one of the networks ‘%s’",
+		"public_ip":          "must be a public IP address",
+		"hex_color":          "must be a valid color code",
+		"color_func":         "must be a valid color",
+		"color_range":        "%s component must be %s",
+		"len_longer":         "must be longer than %d characters",
+		"len_shorter":        "must be shorter than %d characters",
+		"words_longer":       "must be longer than %d words",
+		"words_shorter":      "must be shorter than %d words",
+		"slice_len_min":      "must have at least %d items",
+		"slice_len_max":      "must have at most %d items",
+		"exclude":            "cannot be ‘%s’",
+		"include":            "must be one of ‘%s’",
+		"not_equal":          "must be different",
+		"integer":            "must be a whole number",
+		"numeric":            "must contain only digits",
+		"otp":                "must be a %d-digit code",
+		"pin":                "must be %d digits",
+		"pin_repeated":       "cannot be the same digit repeated",
+		"pin_sequence":       "cannot be a sequence of digits",
+		"pin_common":         "is too common",
+		"alpha":              "must contain only letters",
+		"alphanumeric":       "must contain only letters and digits",
+		"single_line":        "must be a single line",
+		"max_lines":          "must be at most %d lines",
+		"bool":               "must be a boolean",
+		"date":               "must be a date as ‘%s’",
+		"timestamp":          "must be a RFC 3339 timestamp",
+		"date_order":         "cannot be before the start date",
+		"phone":              "must be a valid phone number",
+		"range_higher":       "must be %d or higher",
+		"range_lower":        "must be %d or lower",
+		"number_higher":      "must be %s or higher",
+		"number_lower":       "must be %s or lower",
+		"number_above":       "must be higher than %s",
+		"number_below":       "must be lower than %s",
+		"multiple_of":        "must be a multiple of %s",
+		"positive":           "must be a positive number",
+		"negative":           "must be a negative number",
+		"not_zero":           "must not be zero",
+		"percent":            "must be a percentage between 0 and 100",
+		"utf8":               "must be UTF-8",
+		"nfc":                "must be in Unicode NFC form",
+		"contains":           "cannot contain the characters %s",
+		"no_emoji":           "must not contain emoji",
+		"printable":          "cannot contain control or invisible characters",
+		"no_space":           "cannot start or end with whitespace",
+		"password_hash":      "must be a supported password hash",
+		"safe_path":          "must be a relative path",
+		"safe_path_depth":    "cannot be more than %d levels deep",
+		"file_path":          "must be a valid file path",
+		"country_code":       "must be a valid country code",
+		"subdivision":        "must be a valid subdivision code",
+		"currency":           "must be a valid currency code",
+		"money":              "must be a valid amount",
+		"money_decimals":     "must have at most %d decimal places",
+		"money_negative":     "cannot be negative",
+		"aba_routing_number": "must be a valid routing number",
+		"sort_code":          "must be a valid sort code",
+		"language":           "must be a valid language tag",
+		"locale":             "must be a valid locale",
+		"timezone":           "must be a valid timezone",
+		"regexp":             "must be a valid regular expression: %s",
+		"identifier":         "must be a valid identifier",
+		"unique":             "duplicate value ‘%s’",
+		"schema":             "must match the schema",
+		"schema_type":        "must be of type %s",
+		"schema_pattern":     "must match ‘%s’",
+		"schema_additional":  "is not allowed",
+	},
+	"nl": {
+		"required":           "moet ingevuld zijn",
+		"require_any":        "minstens één moet ingevuld zijn",
+		"domain":             "moet een geldig domein zijn",
+		"hostname":           "moet een geldige hostnaam zijn",
+		"host":               "moet een geldige hostnaam of IP-adres zijn",
+		"host_port":          "moet een geldige host en poort zijn, zoals ‘example.com:8080’",
+		"url":                "moet een geldige url zijn",
+		"url_path":           "moet een geldige url met een pad zijn",
+		"url_no_ip":          "mag geen IP-adres zijn",
+		"url_scheme":         "moet %s bevatten",
+		"query_string":       "moeten geldige URL-parameters zijn",
+		"query_string_keys":  "mag maximaal %d parameters hebben",
+		"file_ext":           "moet een van de extensies ‘%s’ hebben",
+		"csv_max":            "mag maximaal %d items hebben",
+		"email":              "moet een geldig e-mailadres zijn",
+		"ipv4":               "moet een geldig IPv4-adres zijn",
+		"ipv6":               "moet een geldig IPv6-adres zijn",
+		"ip":                 "moet een geldig IPv4- of IPv6-adres zijn",
+		"ip_in":              "moet in een van de netwerken ‘%s’ liggen",
+		"public_ip":          "moet een openbaar IP-adres zijn",
+		"hex_color":          "moet een geldige kleurcode zijn",
+		"color_func":         "moet een geldige kleur zijn",
+		"color_range":        "%s-component moet %s zijn",
+		"len_longer":         "moet langer dan %d tekens zijn",
+		"len_shorter":        "moet korter dan %d tekens zijn",
+		"words_longer":       "moet langer dan %d woorden zijn",
+		"words_shorter":      "moet korter dan %d woorden zijn",
+		"slice_len_min":      "moet minstens %d items hebben",
+		"slice_len_max":      "mag maximaal %d items hebben",
+		"exclude":            "mag niet ‘%s’ zijn",
+		"include":            "moet een van ‘%s’ zijn",
+		"not_equal":          "moet verschillend zijn",
+		"integer":            "moet een geheel getal zijn",
+		"numeric":            "mag alleen cijfers bevatten",
+		"otp":                "moet een code van %d cijfers zijn",
+		"pin":                "moet %d cijfers zijn",
+		"pin_repeated":       "mag niet hetzelfde cijfer herhaald zijn",
+		"pin_sequence":       "mag geen reeks cijfers zijn",
+		"pin_common":         "komt te vaak voor",
+		"alpha":              "mag alleen letters bevatten",
+		"alphanumeric":       "mag alleen letters en cijfers bevatten",
+		"single_line":        "moet één regel zijn",
+		"max_lines":          "mag maximaal %d regels zijn",
+		"bool":               "moet een boolean zijn",
+		"date":               "moet een datum zijn als ‘%s’",
+		"timestamp":          "moet een RFC 3339-tijdstempel zijn",
+		"date_order":         "mag niet voor de begindatum zijn",
+		"phone":              "moet een geldig telefoonnummer zijn",
+		"range_higher":       "moet %d of hoger zijn",
+		"range_lower":        "moet %d of lager zijn",
+		"number_higher":      "moet %s of hoger zijn",
+		"number_lower":       "moet %s of lager zijn",
+		"number_above":       "moet hoger dan %s zijn",
+		"number_below":       "moet lager dan %s zijn",
+		"multiple_of":        "moet een veelvoud van %s zijn",
+		"positive":           "moet een positief getal zijn",
+		"negative":           "moet een negatief getal zijn",
+		"not_zero":           "mag niet nul zijn",
+		"percent":            "moet een percentage tussen 0 en 100 zijn",
+		"utf8":               "moet UTF-8 zijn",
+		"nfc":                "moet in Unicode NFC-vorm zijn",
+		"contains":           "mag de tekens %s niet bevatten",
+		"no_emoji":           "mag geen emoji bevatten",
+		"printable":          "mag geen controle- of onzichtbare tekens bevatten",
+		"no_space":           "mag niet met witruimte beginnen of eindigen",
+		"password_hash":      "moet een ondersteunde wachtwoord-hash zijn",
+		"safe_path":          "moet een relatief pad zijn",
+		"safe_path_depth":    "mag niet meer dan %d niveaus diep zijn",
+		"file_path":          "moet een geldig bestandspad zijn",
+		"country_code":       "moet een geldige landcode zijn",
+		"subdivision":        "moet een geldige regiocode zijn",
+		"currency":           "moet een geldige valutacode zijn",
+		"money":              "moet een geldig bedrag zijn",
+		"money_decimals":     "mag maximaal %d decimalen hebben",
+		"money_negative":     "mag niet negatief zijn",
+		"aba_routing_number": "moet een geldig routingnummer zijn",
+		"sort_code":          "moet een geldige sort code zijn",
+		"language":           "moet een geldige taalcode zijn",
+		"locale":             "moet een geldige locale zijn",
+		"timezone":           "moet een geldige tijdzone zijn",
+		"regexp":             "moet een geldige reguliere expressie zijn: %s",
+		"identifier":         "moet een geldige identifier zijn",
+		"unique":             "dubbele waarde ‘%s’",
+		"schema":             "moet overeenkomen met het schema",
+		"schema_type":        "moet van het type %s zijn",
+		"schema_pattern":     "moet overeenkomen met ‘%s’",
+		"schema_additional":  "is niet toegestaan",
+	},
+}
+
+// messageIDs maps message IDs to the package defaults.
+var messageIDs = map[string]*string{
+	"required":           &MessageRequired,
+	"require_any":        &MessageRequireAny,
+	"domain":             &MessageDomain,
+	"hostname":           &MessageHostname,
+	"host":               &MessageHost,
+	"host_port":          &MessageHostPort,
+	"url":                &MessageURL,
+	"url_path":           &MessageURLPath,
+	"url_no_ip":          &MessageURLNoIP,
+	"url_scheme":         &MessageURLScheme,
+	"query_string":       &MessageQueryString,
+	"query_string_keys":  &MessageQueryStringKeys,
+	"file_ext":           &MessageFileExt,
+	"csv_max":            &MessageCSVMax,
+	"email":              &MessageEmail,
+	"ipv4":               &MessageIPv4,
+	"ipv6":               &MessageIPv6,
+	"ip":                 &MessageIP,
+	"ip_in":              &MessageIPIn,
+	"public_ip":          &MessagePublicIP,
+	"hex_color":          &MessageHexColor,
+	"color_func":         &MessageColorFunc,
+	"color_range":        &MessageColorRange,
+	"len_longer":         &MessageLenLonger,
+	"len_shorter":        &MessageLenShorter,
+	"words_longer":       &MessageWordsLonger,
+	"words_shorter":      &MessageWordsShorter,
+	"slice_len_min":      &MessageSliceLenMin,
+	"slice_len_max":      &MessageSliceLenMax,
+	"exclude":            &MessageExclude,
+	"include":            &MessageInclude,
+	"not_equal":          &MessageNotEqual,
+	"integer":            &MessageInteger,
+	"numeric":            &MessageNumeric,
+	"otp":                &MessageOTP,
+	"pin":                &MessagePIN,
+	"pin_repeated":       &MessagePINRepeated,
+	"pin_sequence":       &MessagePINSequence,
+	"pin_common":         &MessagePINCommon,
+	"alpha":              &MessageAlpha,
+	"alphanumeric":       &MessageAlphanumeric,
+	"single_line":        &MessageSingleLine,
+	"max_lines":          &MessageMaxLines,
+	"bool":               &MessageBool,
+	"date":               &MessageDate,
+	"timestamp":          &MessageTimestamp,
+	"date_order":         &MessageDateOrder,
+	"phone":              &MessagePhone,
+	"range_higher":       &MessageRangeHigher,
+	"range_lower":        &MessageRangeLower,
+	"number_higher":      &MessageNumberHigher,
+	"number_lower":       &MessageNumberLower,
+	"number_above":       &MessageNumberAbove,
+	"number_below":       &MessageNumberBelow,
+	"multiple_of":        &MessageMultipleOf,
+	"positive":           &MessagePositive,
+	"negative":           &MessageNegative,
+	"not_zero":           &MessageNotZero,
+	"percent":            &MessagePercent,
+	"utf8":               &MessageUTF8,
+	"nfc":                &MessageNFC,
+	"contains":           &MessageContains,
+	"no_emoji":           &MessageNoEmoji,
+	"printable":          &MessagePrintable,
+	"no_space":           &MessageNoSpace,
+	"password_hash":      &MessagePasswordHash,
+	"safe_path":          &MessageSafePath,
+	"safe_path_depth":    &MessageSafePathDepth,
+	"file_path":          &MessageFilePath,
+	"country_code":       &MessageCountryCode,
+	"subdivision":        &MessageSubdivision,
+	"currency":           &MessageCurrency,
+	"money":              &MessageMoney,
+	"money_decimals":     &MessageMoneyDecimals,
+	"money_negative":     &MessageMoneyNegative,
+	"aba_routing_number": &MessageABARoutingNumber,
+	"sort_code":          &MessageSortCode,
+	"language":           &MessageLanguage,
+	"locale":             &MessageLocale,
+	"timezone":           &MessageTimezone,
+	"regexp":             &MessageRegexp,
+	"identifier":         &MessageIdentifier,
+	"unique":             &MessageUnique,
+	"schema":             &MessageSchema,
+	"schema_type":        &MessageSchemaType,
+	"schema_pattern":     &MessageSchemaPattern,
+	"schema_additional":  &MessageSchemaAdditional,
+}
+
+// message gets the message for the message ID id, from v.Messages or the
+// package default.
+func (v *Validator) message(id string) string {
+	if m := v.Messages[id]; m != "" {
+		return m
+	}
+	return *messageIDs[id]
+}
+
+// appendMessage appends the message for the message ID id, using id as the
+// code. The custom message is used instead if one was given.
+func (v *Validator) appendMessage(key, id string, message []string, args ...interface{}) {
+	v.appendParams(key, id, nil, message, args...)
+}
+
+// appendParams is like appendMessage, but also records the parameters for the
+// Field.
+func (v *Validator) appendParams(key, id string, params map[string]interface{}, message []string, args ...interface{}) {
+	if msg := getMessage(message, ""); msg != "" {
+		v.appendField(key, id, params, msg)
+		return
+	}
+	v.appendField(key, id, params, fmt.Sprintf(v.message(id), args...))
+}
+
 func getMessage(in []string, def string) string {
 	switch len(in) {
 	case 0:
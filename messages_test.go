@@ -0,0 +1,95 @@
+package zvalidate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"zgo.at/zstd/ztest"
+)
+
+func TestCatalogs(t *testing.T) {
+	params := regexp.MustCompile(`%[a-z]`)
+
+	for lang, c := range Catalogs {
+		t.Run(lang, func(t *testing.T) {
+			for id, def := range messageIDs {
+				m, ok := c[id]
+				if !ok {
+					t.Errorf("missing %q", id)
+					continue
+				}
+				if p, want := params.FindAllString(m, -1), params.FindAllString(*def, -1); !reflect.DeepEqual(p, want) {
+					t.Errorf("%q: parameters %v; want %v", id, p, want)
+				}
+				if lang == "en" && m != *def {
+					t.Errorf("%q: %q; want %q", id, m, *def)
+				}
+			}
+			for id := range c {
+				if _, ok := messageIDs[id]; !ok {
+					t.Errorf("unknown message ID %q", id)
+				}
+			}
+		})
+	}
+}
+
+func TestMessages(t *testing.T) {
+	v := New()
+	v.Messages = Catalogs["nl"]
+	v.Required("required", "")
+	v.Len("len", "x", 2, 0)
+	v.Include("include", "x", []string{"a", "b"})
+	v.Email("custom", "x", "eigen bericht")
+
+	v.Messages = Catalog{"required": "overridden"}
+	v.Required("partial", "")
+	v.Email("fallback", "x")
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"required": {"moet ingevuld zijn"},
+		"len":      {"moet langer dan 2 tekens zijn"},
+		"include":  {"moet een van ‘a, b’ zijn"},
+		"custom":   {"eigen bericht"},
+		"partial":  {"overridden"},
+		"fallback": {"must be a valid email address"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestWithMessages(t *testing.T) {
+	var (
+		a = New(WithMessages(Catalogs["nl"]), WithMessages(Catalog{"required": "verplicht"}))
+		b = New(WithMessages(Catalog{"email": "bad email"}))
+		c = NewConcurrent()
+	)
+	for _, v := range []*Validator{&a, &b, &c} {
+		v.Required("required", "")
+		v.Email("email", "x")
+	}
+
+	tests := []struct {
+		v    Validator
+		want map[string][]string
+	}{
+		{a, map[string][]string{"required": {"verplicht"}, "email": {"moet een geldig e-mailadres zijn"}}},
+		{b, map[string][]string{"required": {"must be set"}, "email": {"bad email"}}},
+		{c, map[string][]string{"required": {"must be set"}, "email": {"must be a valid email address"}}},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if d := ztest.Diff(fmt.Sprintf("%+v", tt.v.Errors), fmt.Sprintf("%+v", tt.want)); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+
+	// Catalogs aren't modified.
+	if m := Catalogs["nl"]["required"]; m != "moet ingevuld zijn" {
+		t.Errorf("Catalogs modified: %q", m)
+	}
+}
@@ -0,0 +1,139 @@
+package zvalidate
+
+import "sort"
+
+//go:generate go run gen_unicode.go 14.0.0 /usr/share/unicode
+
+// normalizeNFC returns s in Unicode Normalization Form C, as described in
+// UAX #15.
+//
+// Invalid UTF-8 is replaced with U+FFFD.
+func normalizeNFC(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return s
+	}
+
+	// Decompose.
+	rs := make([]rune, 0, len(s))
+	for _, r := range s {
+		rs = nfcDecomposeRune(rs, r)
+	}
+
+	// Put combining marks in the canonical order.
+	for i := 1; i < len(rs); i++ {
+		for j := i; j > 0; j-- {
+			a, b := combiningClass(rs[j-1]), combiningClass(rs[j])
+			if b == 0 || a <= b {
+				break
+			}
+			rs[j-1], rs[j] = rs[j], rs[j-1]
+		}
+	}
+
+	// Compose.
+	if len(rs) == 0 {
+		return ""
+	}
+	var (
+		starterPos = 0
+		starter    = rs[0]
+		lastClass  = int(combiningClass(starter))
+		n          = 1
+	)
+	if lastClass != 0 {
+		lastClass = 256 // Never compose with a leading combining mark.
+	}
+	for _, r := range rs[1:] {
+		class := int(combiningClass(r))
+		if c, ok := nfcComposeRunes(starter, r); ok && (lastClass < class || lastClass == 0) {
+			rs[starterPos], starter = c, c
+			continue
+		}
+		if class == 0 {
+			starterPos, starter = n, r
+		}
+		lastClass = class
+		rs[n] = r
+		n++
+	}
+	return string(rs[:n])
+}
+
+// Hangul syllables are composed and decomposed algorithmically.
+const (
+	hangulSBase  = 0xac00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11a7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+func nfcDecomposeRune(rs []rune, r rune) []rune {
+	if r >= hangulSBase && r < hangulSBase+hangulSCount {
+		s := r - hangulSBase
+		rs = append(rs, hangulLBase+s/hangulNCount, hangulVBase+(s%hangulNCount)/hangulTCount)
+		if t := s % hangulTCount; t != 0 {
+			rs = append(rs, hangulTBase+t)
+		}
+		return rs
+	}
+
+	d, ok := nfcDecompose[r]
+	if !ok {
+		return append(rs, r)
+	}
+	rs = nfcDecomposeRune(rs, d[0])
+	if d[1] != 0 {
+		rs = nfcDecomposeRune(rs, d[1])
+	}
+	return rs
+}
+
+func nfcComposeRunes(a, b rune) (rune, bool) {
+	// Hangul L + V
+	if a >= hangulLBase && a < hangulLBase+hangulLCount && b >= hangulVBase && b < hangulVBase+hangulVCount {
+		return hangulSBase + ((a-hangulLBase)*hangulVCount+(b-hangulVBase))*hangulTCount, true
+	}
+	// Hangul LV + T
+	if a >= hangulSBase && a < hangulSBase+hangulSCount && (a-hangulSBase)%hangulTCount == 0 &&
+		b > hangulTBase && b < hangulTBase+hangulTCount {
+		return a + (b - hangulTBase), true
+	}
+
+	c, ok := nfcCompose[[2]rune{a, b}]
+	return c, ok
+}
+
+func combiningClass(r rune) uint8 {
+	if r < 0x300 {
+		return 0
+	}
+	i := sort.Search(len(nfcCombining), func(i int) bool { return nfcCombining[i].hi >= r })
+	if i < len(nfcCombining) && nfcCombining[i].lo <= r {
+		return nfcCombining[i].ccc
+	}
+	return 0
+}
+
+// Primary composites: all two-character canonical decompositions that aren't
+// excluded.
+var nfcCompose = func() map[[2]rune]rune {
+	m := make(map[[2]rune]rune, len(nfcDecompose))
+	for r, d := range nfcDecompose {
+		if _, ok := nfcExclude[r]; !ok && d[1] != 0 {
+			m[d] = r
+		}
+	}
+	return m
+}()
@@ -0,0 +1,36 @@
+package zvalidate
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNormalizeNFC(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{"\u00e9", "\u00e9"},
+		{"e\u0301", "\u00e9"},
+		{"E\u0301", "\u00c9"},
+		{"\u212b", "\u00c5"},                     // ANGSTROM SIGN is a singleton.
+		{"a\u0323\u0302", "\u1ead"},              // Compose out of order.
+		{"a\u0302\u0323", "\u1ead"},              // Reorder combining marks.
+		{"\u0344", "\u0308\u0301"},               // Excluded from composition.
+		{"\u1100\u1161\u11a8", "\uac01"},         // Hangul.
+		{"\u0301e", "\u0301e"},                   // Leading combining mark.
+		{"\U00011099\U000110ba", "\U0001109a"},   // Kaithi.
+		{"\U00010041\u0301", "\U00010041\u0301"}, // Doesn't compose.
+		{"\xff", "\ufffd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+q", tt.in), func(t *testing.T) {
+			got := normalizeNFC(tt.in)
+			if got != tt.want {
+				t.Errorf("normalizeNFC(%+q) = %+q; want %+q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -165,6 +165,14 @@ func TestPunycode(t *testing.T) {
 			if got != tt.s {
 				t.Errorf("\ngot:  %q\nwant: %q", got, tt.s)
 			}
+
+			enc, err := punyEncode(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if enc != tt.encoded {
+				t.Errorf("encode\ngot:  %q\nwant: %q", enc, tt.encoded)
+			}
 		})
 	}
 }
@@ -0,0 +1,343 @@
+package zvalidate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct validates the exported fields of a struct (or pointer to a struct)
+// from the "validate" struct tag; for example:
+//
+//   type User struct {
+//       Email string `json:"email" validate:"required,email"`
+//       Name  string `json:"name"  validate:"len:0-100"`
+//       Age   int    `json:"age"   validate:"range:18-150"`
+//       Role  string `json:"role"  validate:"include:admin|user"`
+//   }
+//
+//   v := zvalidate.New()
+//   v.Struct(user)
+//
+// The key is the name from the json tag, or the field name if there is no json
+// tag; "name:key" in the validate tag overrides this. Fields without a validate
+// tag are skipped, except for nested structs.
+//
+// Nested structs, pointers to structs, and slices or arrays of structs are
+// validated recursively, with the keys added as with Sub() and SubIndex(); for
+// example "address.city" or "addresses[0].city". The only rule for these fields
+// is "required", which checks that the pointer is not nil or that the slice is
+// not empty. Use validate:"-" to skip them. Embedded structs are validated as
+// if the fields are part of the parent struct.
+//
+// Rules map to the validator methods with the same name, and use the same
+// messages:
+//
+//   required                     Required()
+//   email, url, domain, hostname Email(), URL(), Domain(), Hostname()
+//   ip, ipv4                     IP(), IPv4()
+//   hexcolor, phone, utf8        HexColor(), Phone(), UTF8()
+//   identifier                   Identifier()
+//   countrycode, currency        CountryCode(), Currency()
+//   language, timezone           Language(), Timezone()
+//   len:min-max                  Len(); use 0 for no maximum
+//   range:min-max                Range(); use 0 for no maximum
+//   include:a|b, exclude:a|b     Include(), Exclude()
+//
+// Like the validator methods, zero values are valid for the format rules (email
+// to timezone), but not for len, range, and include: validate:"len:1-100" on
+// an empty string is an error, just like Len("name", "", 1, 100).
+//
+// This will panic on unknown rules or if a rule can't be used for the field's
+// type, as that's a programming error.
+func (v *Validator) Struct(s interface{}) {
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("zvalidate.Struct: not a struct: %T", s))
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag := f.Tag.Get("validate")
+		nested := isNestedStruct(f.Type)
+		if (tag == "" && !nested) || tag == "-" || f.PkgPath != "" {
+			continue
+		}
+
+		key := f.Name
+		if j := strings.Split(f.Tag.Get("json"), ",")[0]; j != "" && j != "-" {
+			key = j
+		}
+
+		var rules []string
+		if tag != "" {
+			for _, r := range strings.Split(tag, ",") {
+				r = strings.TrimSpace(r)
+				if strings.HasPrefix(r, "name:") {
+					key = r[5:]
+					continue
+				}
+				rules = append(rules, r)
+			}
+		}
+
+		where := "zvalidate.Struct: field " + f.Name
+		if nested && f.Anonymous && f.Type.Kind() == reflect.Struct && tag == "" {
+			// Embedded struct: add the fields as if they're part of this struct.
+			v.Struct(rv.Field(i).Interface())
+			continue
+		}
+		if nested {
+			v.structNested(where, key, rules, rv.Field(i))
+			continue
+		}
+		for _, rule := range rules {
+			v.rule(where, key, parseRule(where, rule), rv.Field(i))
+		}
+	}
+}
+
+// isNestedStruct reports if t is a struct, pointer to a struct, or slice or
+// array of those which should be validated with Struct().
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct &&
+		t != reflect.TypeOf(time.Time{}) && t != reflect.TypeOf(mail.Address{})
+}
+
+// structNested validates a nested struct field.
+func (v *Validator) structNested(where, key string, rules []string, val reflect.Value) {
+	for _, r := range rules {
+		if r != "required" {
+			if _, ok := ruleNames[r]; !ok {
+				panic(fmt.Sprintf("%s: unknown rule %q", where, r))
+			}
+			panic(fmt.Sprintf("%s: rule %q can't be used on %s", where, r, val.Type()))
+		}
+		switch val.Kind() {
+		case reflect.Ptr:
+			if val.IsNil() {
+				v.appendMessage(key, "required", nil)
+				return
+			}
+		case reflect.Slice, reflect.Array:
+			if val.Len() == 0 {
+				v.appendMessage(key, "required", nil)
+				return
+			}
+		}
+	}
+
+	sub := func(val reflect.Value) Validator {
+		s := New(WithMessages(v.Messages))
+		if val.Kind() != reflect.Ptr || !val.IsNil() {
+			s.Struct(val.Interface())
+		}
+		return s
+	}
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			v.SubIndex(key, i, sub(val.Index(i)))
+		}
+	default:
+		v.Sub(key, "", sub(val))
+	}
+}
+
+// Rule is a validation rule for Map().
+type Rule struct {
+	Name   string   // Rule name, such as "email" or "len"; see Struct() for a list.
+	Params []string // Parameters, such as {"1", "10"} for "len" or {"a", "b"} for "include".
+}
+
+// Map validates the values in data with the rules for every key; for example:
+//
+//   v.Map(form, map[string][]zvalidate.Rule{
+//       "email": {{Name: "required"}, {Name: "email"}},
+//       "name":  {{Name: "len", Params: []string{"0", "100"}}},
+//   })
+//
+// This is useful for dynamic forms where there isn't a struct. Keys that are
+// missing from data are validated as "", and keys without rules are ignored.
+// The rules are the same as for Struct(), and it will panic on unknown rules.
+//
+// Values for "range" are parsed as with Integer() first; the range isn't
+// checked if this fails.
+func (v *Validator) Map(data map[string]string, rules map[string][]Rule) {
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		where := "zvalidate.Map: key " + k
+		for _, r := range rules[k] {
+			val := reflect.ValueOf(data[k])
+			if r.Name == "range" {
+				ruleRange(where, r)
+				n, err := strconv.ParseInt(strings.TrimSpace(data[k]), 10, 64)
+				if err != nil && data[k] != "" {
+					v.appendMessage(k, "integer", nil)
+					continue
+				}
+				val = reflect.ValueOf(n)
+			}
+			v.rule(where, k, r, val)
+		}
+	}
+}
+
+var ruleNames = map[string]struct{}{
+	"required": {}, "email": {}, "url": {}, "domain": {}, "hostname": {},
+	"ip": {}, "ipv4": {}, "hexcolor": {}, "phone": {}, "utf8": {},
+	"identifier": {}, "countrycode": {}, "currency": {}, "language": {},
+	"timezone": {}, "len": {}, "range": {}, "include": {}, "exclude": {},
+}
+
+// parseRule parses a rule from a struct tag, such as "email", "len:1-10", or
+// "include:a|b".
+func parseRule(where, rule string) Rule {
+	name, param := rule, ""
+	if i := strings.IndexByte(rule, ':'); i > -1 {
+		name, param = rule[:i], rule[i+1:]
+	}
+
+	r := Rule{Name: name}
+	switch name {
+	case "len", "range":
+		// min may be negative: "-10-40".
+		i := strings.IndexByte(param, '-')
+		if i == 0 {
+			i = strings.IndexByte(param[1:], '-') + 1
+		}
+		if i <= 0 {
+			panic(fmt.Sprintf("%s: invalid rule %q: want min-max", where, rule))
+		}
+		r.Params = []string{param[:i], param[i+1:]}
+	case "include", "exclude":
+		r.Params = strings.Split(param, "|")
+	}
+	return r
+}
+
+// rule applies the rule r to val.
+//
+// where is used in panic messages; for example "zvalidate.Struct: field Email".
+func (v *Validator) rule(where, key string, r Rule, val reflect.Value) {
+	if _, ok := ruleNames[r.Name]; !ok {
+		panic(fmt.Sprintf("%s: unknown rule %q", where, r.Name))
+	}
+
+	if r.Name == "required" {
+		v.Required(key, val.Interface())
+		return
+	}
+
+	// Check the type and parameters before skipping zero values, so that
+	// mistakes are always reported.
+	var min, max int64
+	if r.Name == "len" || r.Name == "range" {
+		min, max = ruleRange(where, r)
+	}
+	wantKind := "string"
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		wantKind = "int"
+	case reflect.String:
+	default:
+		wantKind = ""
+	}
+	if (r.Name == "range") != (wantKind == "int") || wantKind == "" {
+		panic(fmt.Sprintf("%s: rule %q can't be used on %s", where, r.Name, val.Type()))
+	}
+
+	// Like the validator methods, the format rules treat zero values as "not
+	// given"; len, range, include, and exclude always check the value.
+	switch r.Name {
+	case "len", "range", "include", "exclude":
+	default:
+		if val.IsZero() {
+			return
+		}
+	}
+
+	if r.Name == "range" {
+		if val.Kind() >= reflect.Uint && val.Kind() <= reflect.Uint64 {
+			v.Range(key, int64(val.Uint()), min, max)
+		} else {
+			v.Range(key, val.Int(), min, max)
+		}
+		return
+	}
+
+	str := val.String()
+	switch r.Name {
+	case "email":
+		v.Email(key, str)
+	case "url":
+		v.URL(key, str)
+	case "domain":
+		v.Domain(key, str)
+	case "hostname":
+		v.Hostname(key, str)
+	case "ip":
+		v.IP(key, str)
+	case "ipv4":
+		v.IPv4(key, str)
+	case "hexcolor":
+		v.HexColor(key, str)
+	case "phone":
+		v.Phone(key, str)
+	case "utf8":
+		v.UTF8(key, str)
+	case "identifier":
+		v.Identifier(key, str)
+	case "countrycode":
+		v.CountryCode(key, str)
+	case "currency":
+		v.Currency(key, str)
+	case "language":
+		v.Language(key, str)
+	case "timezone":
+		v.Timezone(key, str)
+	case "len":
+		v.Len(key, str, int(min), int(max))
+	case "include":
+		v.Include(key, str, r.Params)
+	case "exclude":
+		v.Exclude(key, str, r.Params)
+	}
+}
+
+// ruleRange gets the min and max parameters for "len" and "range".
+func ruleRange(where string, r Rule) (int64, int64) {
+	if len(r.Params) != 2 {
+		panic(fmt.Sprintf("%s: rule %q: want min and max parameters", where, r.Name))
+	}
+
+	min, err := strconv.ParseInt(r.Params[0], 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("%s: rule %q: %s", where, r.Name, err))
+	}
+	max, err := strconv.ParseInt(r.Params[1], 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("%s: rule %q: %s", where, r.Name, err))
+	}
+	return min, max
+}
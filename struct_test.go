@@ -0,0 +1,277 @@
+package zvalidate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"zgo.at/zstd/ztest"
+)
+
+func TestStruct(t *testing.T) {
+	type user struct {
+		Email    string `json:"email" validate:"required,email"`
+		Name     string `validate:"len:2-5"`
+		Age      int    `json:"age,omitempty" validate:"range:18-150"`
+		Temp     int64  `json:"temp" validate:"range:-10-40"`
+		Role     string `json:"role" validate:"include:admin|user"`
+		Site     string `json:"site" validate:"url"`
+		Skip     string `json:"skip" validate:"-"`
+		NoTag    string `json:"notag"`
+		unexport string `validate:"required"`
+	}
+
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{user{Email: "a@example.com", Name: "Martin", Age: 42, Role: "admin"},
+			"map[Name:[must be shorter than 5 characters]]"},
+		{&user{Email: "a@example.com", Name: "Bob", Age: 42, Temp: -5, Role: "user", Site: "https://example.com"},
+			"map[]"},
+		{user{},
+			"map[Name:[must be longer than 2 characters] age:[must be 18 or higher] " +
+				"email:[must be set] role:[must be one of ‘admin, user’]]"},
+		{user{Email: "a@example.com", Name: "x", Age: 42, Role: "user"},
+			"map[Name:[must be longer than 2 characters]]"},
+		{user{Email: "x", Name: "Bob", Age: 10, Temp: -11, Role: "x", Site: "x"},
+			"map[age:[must be 18 or higher] " +
+				"email:[must be a valid email address] role:[must be one of ‘admin, user’] " +
+				"site:[must be a valid url] temp:[must be -10 or higher]]"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			v := New()
+			v.Struct(tt.in)
+			if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+
+	t.Run("panic", func(t *testing.T) {
+		tests := []interface{}{
+			"not a struct",
+			struct {
+				F string `validate:"unknown"`
+			}{},
+			struct {
+				F int `validate:"email"`
+			}{},
+			struct {
+				F string `validate:"range:1-2"`
+			}{},
+			struct {
+				F string `validate:"len:5"`
+			}{},
+		}
+		for i, tt := range tests {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Error("no panic")
+					}
+				}()
+				v := New()
+				v.Struct(tt)
+			})
+		}
+	})
+}
+
+// Zero values should give the same errors as calling the methods directly.
+func TestStructZero(t *testing.T) {
+	type zero struct {
+		Name  string `json:"name" validate:"len:5-10"`
+		Age   int    `json:"age" validate:"range:18-150"`
+		Role  string `json:"role" validate:"include:admin|user"`
+		Email string `json:"email" validate:"email"`
+		Site  string `json:"site" validate:"url"`
+	}
+
+	v := New()
+	v.Struct(zero{})
+
+	want := New()
+	want.Len("name", "", 5, 10)
+	want.Range("age", 0, 18, 150)
+	want.Include("role", "", []string{"admin", "user"})
+	want.Email("email", "")
+	want.URL("site", "")
+
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), fmt.Sprintf("%+v", want.Errors)); d != "" {
+		t.Error(d)
+	}
+	if len(v.Errors) != 3 {
+		t.Errorf("want 3 errors: %v", v.Errors)
+	}
+}
+
+func TestStructNested(t *testing.T) {
+	type address struct {
+		City string `json:"city" validate:"required"`
+		Zip  string `validate:"name:zip,len:4-6"`
+	}
+	type Base struct {
+		ID string `json:"id" validate:"required"`
+	}
+	type customer struct {
+		Base
+		Base2 struct {
+			Name string `validate:"required"`
+		}
+		Address   address    `json:"address"`
+		Billing   *address   `json:"billing" validate:"required"`
+		Shipping  *address   `json:"shipping"`
+		Addresses []address  `json:"addresses" validate:"required"`
+		Others    []*address `json:"others"`
+		Skip      address    `validate:"-"`
+		Created   time.Time  `json:"created" validate:"required"`
+	}
+
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{customer{
+			Base: Base{ID: "1"},
+			Base2: struct {
+				Name string `validate:"required"`
+			}{"x"},
+			Address:   address{City: "Bristol", Zip: "BS1 4"},
+			Billing:   &address{City: "Bristol", Zip: "BS1 4"},
+			Addresses: []address{{City: "Bristol", Zip: "BS1 4"}},
+			Others:    []*address{nil},
+			Created:   time.Now(),
+		}, "map[]"},
+		{customer{},
+			"map[Base2.Name:[must be set] address.city:[must be set] " +
+				"address.zip:[must be longer than 4 characters] addresses:[must be set] " +
+				"billing:[must be set] created:[must be set] id:[must be set]]"},
+		{customer{
+			Base: Base{ID: "1"},
+			Base2: struct {
+				Name string `validate:"required"`
+			}{"x"},
+			Address:   address{City: "Bristol", Zip: "1"},
+			Billing:   &address{},
+			Shipping:  &address{Zip: "1234567"},
+			Addresses: []address{{City: "Bristol", Zip: "BS1 4"}, {}},
+			Others:    []*address{nil, {}},
+			Created:   time.Now(),
+		},
+			"map[address.zip:[must be longer than 4 characters] addresses[1].city:[must be set] " +
+				"addresses[1].zip:[must be longer than 4 characters] " +
+				"billing.city:[must be set] billing.zip:[must be longer than 4 characters] " +
+				"others[1].city:[must be set] others[1].zip:[must be longer than 4 characters] " +
+				"shipping.city:[must be set] shipping.zip:[must be shorter than 6 characters]]"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			v := New()
+			v.Struct(tt.in)
+			if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+
+	t.Run("panic", func(t *testing.T) {
+		tests := []interface{}{
+			struct {
+				F struct{} `validate:"email"`
+			}{},
+			struct {
+				F []struct{} `validate:"unknown"`
+			}{},
+		}
+		for i, tt := range tests {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Error("no panic")
+					}
+				}()
+				v := New()
+				v.Struct(tt)
+			})
+		}
+	})
+}
+
+func TestMap(t *testing.T) {
+	rules := map[string][]Rule{
+		"email": {{Name: "required"}, {Name: "email"}},
+		"name":  {{Name: "len", Params: []string{"2", "5"}}},
+		"role":  {{Name: "include", Params: []string{"admin", "user"}}},
+		"age":   {{Name: "range", Params: []string{"18", "150"}}},
+	}
+
+	tests := []struct {
+		in   map[string]string
+		want string
+	}{
+		{map[string]string{"email": "a@example.com", "name": "Bob", "role": "user", "age": "42", "other": "x"},
+			"map[]"},
+		{map[string]string{"email": "a@example.com", "name": "Bob", "role": "user", "age": " 18 "},
+			"map[]"},
+		{nil,
+			"map[age:[must be 18 or higher] email:[must be set] " +
+				"name:[must be longer than 2 characters] role:[must be one of ‘admin, user’]]"},
+		{map[string]string{"email": "x", "name": "Martin", "role": "x", "age": "42"},
+			"map[email:[must be a valid email address] name:[must be shorter than 5 characters] role:[must be one of ‘admin, user’]]"},
+		{map[string]string{"email": "a@example.com", "name": "Bob", "role": "user", "age": "17"},
+			"map[age:[must be 18 or higher]]"},
+		{map[string]string{"email": "a@example.com", "name": "Bob", "role": "user", "age": "151"},
+			"map[age:[must be 150 or lower]]"},
+		{map[string]string{"email": "a@example.com", "name": "Bob", "role": "user", "age": "x"},
+			"map[age:[must be a whole number]]"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			v := New()
+			v.Map(tt.in, rules)
+			if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), tt.want); d != "" {
+				t.Error(d)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		v := New()
+		v.Map(map[string]string{"a": ""}, map[string][]Rule{
+			"a": {{Name: "len", Params: []string{"5", "100"}}},
+			"b": {{Name: "len", Params: []string{"5", "100"}}},
+			"c": {{Name: "len", Params: []string{"0", "100"}}},
+			"d": {{Name: "email"}},
+		})
+		want := "map[a:[must be longer than 5 characters] b:[must be longer than 5 characters]]"
+		if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+			t.Error(d)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		tests := []map[string][]Rule{
+			{"k": {{Name: "unknown"}}},
+			{"k": {{Name: "range", Params: []string{"1"}}}},
+			{"k": {{Name: "range", Params: []string{"x", "2"}}}},
+			{"k": {{Name: "len", Params: []string{"1"}}}},
+			{"k": {{Name: "len", Params: []string{"1", "x"}}}},
+		}
+		for i, tt := range tests {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Error("no panic")
+					}
+				}()
+				v := New()
+				v.Map(nil, tt)
+			})
+		}
+	})
+}
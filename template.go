@@ -36,3 +36,47 @@ func TemplateHasErrors(v *Validator) bool {
 	}
 	return v.HasErrors()
 }
+
+// TemplateFuncs gets template functions for displaying validation errors:
+//
+//   validate_has .V "key"           Report if key has errors.
+//   validate_errs .V "key"          Get the list of errors for key.
+//   validate_class .V "key" [class] Get class if key has errors; the default
+//                                   class is "is-invalid".
+//
+// The Validator can be a Validator, *Validator, or nil, so it's safe to render
+// a page before anything is validated. For example:
+//
+//   <input name="email" class="{{validate_class .V "email"}}">
+//   {{range validate_errs .V "email"}}<span class="err">{{.}}</span>{{end}}
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"validate_has": func(v interface{}, key string) bool {
+			return templateValidator(v).HasKey(key)
+		},
+		"validate_errs": func(v interface{}, key string) []string {
+			return templateValidator(v).ErrorsFor(key)
+		},
+		"validate_class": func(v interface{}, key string, class ...string) string {
+			if !templateValidator(v).HasKey(key) {
+				return ""
+			}
+			if len(class) > 0 {
+				return class[0]
+			}
+			return "is-invalid"
+		},
+	}
+}
+
+func templateValidator(v interface{}) *Validator {
+	switch vv := v.(type) {
+	case *Validator:
+		if vv != nil {
+			return vv
+		}
+	case Validator:
+		return &vv
+	}
+	return &Validator{}
+}
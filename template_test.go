@@ -3,6 +3,7 @@ package zvalidate
 import (
 	"fmt"
 	"html/template"
+	"strings"
 	"testing"
 )
 
@@ -48,3 +49,41 @@ func TestTemplateHasErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateFuncs(t *testing.T) {
+	tpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(
+		`<input name="email" class="{{validate_class .V "email"}}">` +
+			`{{range validate_errs .V "email"}}<span class="err">{{.}}</span>{{end}}` +
+			`{{if validate_has .V "name"}}name{{end}}` +
+			`<input class="{{validate_class .V "email" "bad"}}">`))
+
+	v := New()
+	v.Append("email", "must be set")
+	v.Append("email", "<b>")
+
+	tests := []struct {
+		in   interface{}
+		want string
+	}{
+		{nil, `<input name="email" class=""><input class="">`},
+		{(*Validator)(nil), `<input name="email" class=""><input class="">`},
+		{Validator{}, `<input name="email" class=""><input class="">`},
+		{&v, `<input name="email" class="is-invalid"><span class="err">must be set</span>` +
+			`<span class="err">&lt;b&gt;</span><input class="bad">`},
+		{v, `<input name="email" class="is-invalid"><span class="err">must be set</span>` +
+			`<span class="err">&lt;b&gt;</span><input class="bad">`},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var b strings.Builder
+			err := tpl.Execute(&b, map[string]interface{}{"V": tt.in})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out := b.String(); out != tt.want {
+				t.Errorf("\nout:  %q\nwant: %q", out, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package zvalidate
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/mail"
 	"net/url"
+	"path"
+	"reflect"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -16,14 +21,97 @@ import (
 
 // Required validates that the value is not the type's zero value.
 //
-// Currently supported types are string, int, int64, uint, uint64, bool,
-// []string, and mail.Address. It will panic if the type is not supported.
+// A string is not set if it's only whitespace, a []byte if it's all zero
+// bytes, a []string if all entries are "", a mail.Address if the Address is
+// blank, and a time.Time if IsZero() is true. Any other slice or map is not set
+// if it's empty, and other types such as numbers, bools, and structs if they're
+// the zero value.
+//
+// Pointers are not set if they're nil, or if the value they point to is not
+// set. For example a *string pointing to "" is not set; use RequiredFunc() if
+// you want to check for "present but zero":
+//
+//   v.RequiredFunc("name", func() bool { return name == nil })
+//
+// It will panic if the type is not supported, such as a func or chan.
 func (v *Validator) Required(key string, value interface{}, message ...string) {
-	msg := getMessage(message, MessageRequired)
+	if isZero(value) {
+		v.appendMessage(key, "required", message)
+	}
+}
+
+// RequiredIf validates that the value is set as with Required(), but only if
+// cond is true. For example:
+//
+//   v.RequiredIf("company", f.Company, f.IsBusiness)
+//
+// The value isn't checked if cond is false, so it won't panic for unsupported
+// types.
+func (v *Validator) RequiredIf(key string, value interface{}, cond bool, message ...string) {
+	if cond {
+		v.Required(key, value, message...)
+	}
+}
+
+// RequiredUnless is like RequiredIf(), but the value is required only if cond
+// is false.
+func (v *Validator) RequiredUnless(key string, value interface{}, cond bool, message ...string) {
+	v.RequiredIf(key, value, !cond, message...)
+}
+
+// RequiredTrim validates that the value is not empty after removing leading
+// and trailing whitespace, and returns the trimmed value.
+//
+// This uses the same rules as Required() for strings.
+func (v *Validator) RequiredTrim(key, value string, message ...string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		v.appendMessage(key, "required", message)
+	}
+	return value
+}
+
+// RequiredFunc validates that the value is set, as reported by isEmpty.
+//
+// This is useful if the zero value is meaningful; for example if 0 is a valid
+// quantity but -1 means "not set":
+//
+//   v.RequiredFunc("quantity", func() bool { return quantity == -1 })
+func (v *Validator) RequiredFunc(key string, isEmpty func() bool, message ...string) {
+	if isEmpty() {
+		v.appendMessage(key, "required", message)
+	}
+}
+
+// RequiredKey validates that key is present in data, for example after
+// decoding JSON to a map[string]interface{}.
+//
+// Unlike Required() this only checks if the key exists, so a key that's present
+// with a zero value such as "" or null is valid.
+func (v *Validator) RequiredKey(key string, data map[string]interface{}, message ...string) {
+	if _, ok := data[key]; !ok {
+		v.appendMessage(key, "required", message)
+	}
+}
+
+// RequireAny validates that at least one of the values is not the type's zero
+// value.
+//
+// This is useful for things like "set either a phone number or email address".
+// The same types as Required() are supported.
+func (v *Validator) RequireAny(key string, values ...interface{}) {
+	for _, val := range values {
+		if !isZero(val) {
+			return
+		}
+	}
+	v.appendMessage(key, "require_any", nil)
+}
 
+// isZero reports if value is the type's zero value, as described in Required().
+func isZero(value interface{}) bool {
 	if value == nil {
-		v.Append(key, msg)
-		return
+		return true
 	}
 
 	var isnil bool
@@ -38,96 +126,83 @@ func (v *Validator) Required(key string, value interface{}, message ...string) {
 		isnil = val == nil
 	case *uint64:
 		isnil = val == nil
+	case *float32:
+		isnil = val == nil
+	case *float64:
+		isnil = val == nil
 	case *mail.Address:
 		isnil = val == nil
 	case *time.Time:
 		isnil = val == nil
 	}
 	if isnil {
-		v.Append(key, msg)
-		return
+		return true
 	}
 
 check:
 	switch val := value.(type) {
 	default:
-		// This is an appropiate use of panic, as it's a programming error that
-		// should be displayed ASAP. Adding a "validation error" would be
-		// inappropriate, and returning an error cumbersome.
-		panic(fmt.Sprintf("zvalidate: not a supported type: %T", value))
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Ptr:
+			if rv.IsNil() {
+				return true
+			}
+			value = rv.Elem().Interface()
+			goto check
+		case reflect.Slice, reflect.Map:
+			return rv.Len() == 0
+		case reflect.String:
+			return strings.TrimSpace(rv.String()) == ""
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			// This is an appropiate use of panic, as it's a programming error
+			// that should be displayed ASAP. Adding a "validation error" would
+			// be inappropriate, and returning an error cumbersome.
+			panic(fmt.Sprintf("zvalidate: not a supported type: %T", value))
+		default:
+			return rv.IsZero()
+		}
 
 	case string:
-		if strings.TrimSpace(val) == "" {
-			v.Append(key, msg)
-		}
+		return strings.TrimSpace(val) == ""
 	case int:
-		if val == int(0) {
-			v.Append(key, msg)
-		}
+		return val == int(0)
 	case int64:
-		if val == int64(0) {
-			v.Append(key, msg)
-		}
+		return val == int64(0)
 	case uint:
-		if val == uint(0) {
-			v.Append(key, msg)
-		}
+		return val == uint(0)
 	case uint64:
-		if val == uint64(0) {
-			v.Append(key, msg)
-		}
+		return val == uint64(0)
+	case float32:
+		return val == float32(0)
+	case float64:
+		return val == float64(0)
 	case bool:
-		if !val {
-			v.Append(key, msg)
-		}
+		return !val
 
 	case []byte:
-		if len(val) == 0 {
-			v.Append(key, msg)
-			return
-		}
-
 		// Make sure there is at least one non-empty entry.
-		nonEmpty := false
 		for i := range val {
 			if val[i] != 0 {
-				nonEmpty = true
-				break
+				return false
 			}
 		}
-		if !nonEmpty {
-			v.Append(key, msg)
-		}
+		return true
 	case []int64:
-		if len(val) == 0 {
-			v.Append(key, msg)
-		}
+		return len(val) == 0
 	case []string:
-		if len(val) == 0 {
-			v.Append(key, msg)
-			return
-		}
-
 		// Make sure there is at least one non-empty entry.
-		nonEmpty := false
 		for i := range val {
 			if val[i] != "" { // Consider " " to be non-empty on purpose.
-				nonEmpty = true
-				break
+				return false
 			}
 		}
-		if !nonEmpty {
-			v.Append(key, msg)
-		}
+		return true
 
 	case mail.Address:
-		if val.Address == "" {
-			v.Append(key, msg)
-		}
+		return val.Address == ""
 	case time.Time:
-		if val.IsZero() {
-			v.Append(key, msg)
-		}
+		return val.IsZero()
 
 	case *string:
 		value = *val
@@ -144,6 +219,12 @@ check:
 	case *uint64:
 		value = *val
 		goto check
+	case *float32:
+		value = *val
+		goto check
+	case *float64:
+		value = *val
+		goto check
 	case *mail.Address:
 		value = *val
 		goto check
@@ -158,16 +239,10 @@ check:
 // This list is matched case-insensitive; the returned value is the same as
 // value.
 func (v *Validator) Exclude(key, value string, exclude []string, message ...string) string {
-	msg := getMessage(message, "")
-
 	val := strings.TrimSpace(strings.ToLower(value))
 	for _, e := range exclude {
 		if strings.EqualFold(e, val) {
-			if msg != "" {
-				v.Append(key, msg)
-			} else {
-				v.Append(key, fmt.Sprintf(MessageExclude, e))
-			}
+			v.appendParams(key, "exclude", map[string]interface{}{"values": exclude}, message, e)
 			return ""
 		}
 	}
@@ -191,37 +266,208 @@ func (v *Validator) Include(key, value string, include []string, message ...stri
 		}
 	}
 
-	msg := getMessage(message, "")
-	if msg != "" {
-		v.Append(key, msg)
-	} else {
-		v.Append(key, fmt.Sprintf(MessageInclude, strings.Join(include, ", ")))
+	v.appendParams(key, "include", map[string]interface{}{"values": include}, message, strings.Join(include, ", "))
+	return ""
+}
+
+// ExcludeCase is like Exclude, but the list is matched exactly, instead of
+// case-insensitive.
+func (v *Validator) ExcludeCase(key, value string, exclude []string, message ...string) string {
+	for _, e := range exclude {
+		if e == value {
+			v.appendParams(key, "exclude", map[string]interface{}{"values": exclude}, message, e)
+			return ""
+		}
+	}
+
+	return value
+}
+
+// IncludeCase is like Include, but the list is matched exactly, instead of
+// case-insensitive. This is useful for values where the case is significant,
+// such as tokens.
+func (v *Validator) IncludeCase(key, value string, include []string, message ...string) string {
+	if len(include) == 0 {
+		return value
+	}
+
+	for _, e := range include {
+		if e == value {
+			return e
+		}
 	}
+
+	v.appendParams(key, "include", map[string]interface{}{"values": include}, message, strings.Join(include, ", "))
 	return ""
 }
 
+// ExcludeInt validates that the value is not in the exclude list.
+//
+// Unlike Required(), 0 is not treated as special and is validated like any
+// other number.
+func (v *Validator) ExcludeInt(key string, value int64, exclude []int64, message ...string) {
+	for _, e := range exclude {
+		if e == value {
+			v.appendParams(key, "exclude", map[string]interface{}{"values": exclude}, message, strconv.FormatInt(e, 10))
+			return
+		}
+	}
+}
+
+// IncludeInt validates that the value is in the include list, for example for
+// numeric status codes:
+//
+//   v.IncludeInt("status", int64(status), []int64{StatusActive, StatusDisabled})
+//
+// The error lists the allowed values, as with Include(). Unlike Required(), 0
+// is not treated as special and is validated like any other number.
+func (v *Validator) IncludeInt(key string, value int64, include []int64, message ...string) {
+	if len(include) == 0 {
+		return
+	}
+
+	for _, e := range include {
+		if e == value {
+			return
+		}
+	}
+
+	l := make([]string, len(include))
+	for i := range include {
+		l[i] = strconv.FormatInt(include[i], 10)
+	}
+	v.appendParams(key, "include", map[string]interface{}{"values": include}, message, strings.Join(l, ", "))
+}
+
+// NotEqual validates that the value is not the same as other.
+//
+// This is useful for things like "the new password must be different from the
+// current one". The comparison is case-sensitive.
+func (v *Validator) NotEqual(key, value, other string, message ...string) {
+	if value == "" {
+		return
+	}
+
+	if value == other {
+		v.appendMessage(key, "not_equal", message)
+	}
+}
+
 // Range sets the minimum and maximum value of a integer.
 //
 // A maximum of 0 indicates there is no upper limit.
 func (v *Validator) Range(key string, value, min, max int64, message ...string) {
-	msg := getMessage(message, "")
-
 	if value < min {
-		if msg != "" {
-			v.Append(key, msg)
-		} else {
-			v.Append(key, fmt.Sprintf(MessageRangeHigher, min))
-		}
+		v.appendParams(key, "range_higher", map[string]interface{}{"min": min}, message, min)
 	}
 	if max > 0 && value > max {
-		if msg != "" {
-			v.Append(key, msg)
-		} else {
-			v.Append(key, fmt.Sprintf(MessageRangeLower, max))
-		}
+		v.appendParams(key, "range_lower", map[string]interface{}{"max": max}, message, max)
+	}
+}
+
+// MultipleOf validates that value is a multiple of n.
+//
+// This will panic if n is 0 or negative.
+func (v *Validator) MultipleOf(key string, value, n int64, message ...string) {
+	if n <= 0 {
+		panic(fmt.Sprintf("zvalidate.MultipleOf: n must be higher than 0: %d", n))
+	}
+	if value%n == 0 {
+		return
+	}
+
+	v.appendParams(key, "multiple_of", map[string]interface{}{"n": n}, message, strconv.FormatInt(n, 10))
+}
+
+// MultipleOfFloat validates that value is a multiple of n, allowing for a
+// difference of epsilon to account for floating point rounding errors (e.g.
+// 0.3 is not exactly a multiple of 0.1).
+//
+// This will panic if n is 0 or negative.
+func (v *Validator) MultipleOfFloat(key string, value, n, epsilon float64, message ...string) {
+	if n <= 0 {
+		panic(fmt.Sprintf("zvalidate.MultipleOfFloat: n must be higher than 0: %s", formatFloat(n)))
+	}
+	if math.Abs(value-math.Round(value/n)*n) <= epsilon {
+		return
+	}
+
+	v.appendParams(key, "multiple_of", map[string]interface{}{"n": n}, message, formatFloat(n))
+}
+
+// Positive validates that value is higher than 0.
+//
+// Unlike most validators, 0 is not valid.
+func (v *Validator) Positive(key string, value int64, message ...string) {
+	if value <= 0 {
+		v.appendMessage(key, "positive", message)
+	}
+}
+
+// PositiveFloat validates that value is higher than 0.
+//
+// Unlike most validators, 0 is not valid.
+func (v *Validator) PositiveFloat(key string, value float64, message ...string) {
+	if !(value > 0) {
+		v.appendMessage(key, "positive", message)
+	}
+}
+
+// Negative validates that value is lower than 0.
+//
+// Unlike most validators, 0 is not valid.
+func (v *Validator) Negative(key string, value int64, message ...string) {
+	if value >= 0 {
+		v.appendMessage(key, "negative", message)
+	}
+}
+
+// NegativeFloat validates that value is lower than 0.
+//
+// Unlike most validators, 0 is not valid.
+func (v *Validator) NegativeFloat(key string, value float64, message ...string) {
+	if !(value < 0) {
+		v.appendMessage(key, "negative", message)
+	}
+}
+
+// NotZero validates that value is not 0.
+//
+// This is useful for values that are always set, but where 0 is not a valid
+// value; Required() is more appropriate if 0 means "not set".
+func (v *Validator) NotZero(key string, value int64, message ...string) {
+	if value == 0 {
+		v.appendMessage(key, "not_zero", message)
 	}
 }
 
+// NotZeroFloat validates that value is not 0.
+//
+// This is useful for values that are always set, but where 0 is not a valid
+// value; Required() is more appropriate if 0 means "not set".
+func (v *Validator) NotZeroFloat(key string, value float64, message ...string) {
+	if value == 0 {
+		v.appendMessage(key, "not_zero", message)
+	}
+}
+
+// Percent parses a percentage between 0 and 100, such as "12.5" or "12.5%".
+//
+// Returns the number, without dividing it by 100.
+func (v *Validator) Percent(key, value string, message ...string) float64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, "%")), 64)
+	if err != nil || !(f >= 0 && f <= 100) {
+		v.appendMessage(key, "percent", message)
+		return 0
+	}
+	return f
+}
+
 // Domain parses a domain as individual labels.
 //
 // A domain must consist of at least two labels. So "com" or "localhost" – while
@@ -231,19 +477,50 @@ func (v *Validator) Range(key string, value, min, max int64, message ...string)
 //
 // This works for internationalized domain names (IDN), either as UTF-8
 // characters or as punycode.
+//
+// Labels can't start or end with a hyphen, can be at most 63 bytes, and the
+// entire domain can be at most 253 bytes. The lengths are checked for the
+// punycode form, which may be longer than the UTF-8 form.
 func (v *Validator) Domain(key, value string, message ...string) []string {
 	if value == "" {
 		return nil
 	}
 
-	msg := getMessage(message, MessageDomain)
+	msg := getMessage(message, v.message("domain"))
 	labels, err := validDomain(value, 2)
 	if err != nil {
-		v.Append(key, fmt.Sprintf("%s: %s", msg, err))
+		v.AppendCode(key, "domain", fmt.Sprintf("%s: %s", msg, err))
 	}
 	return labels
 }
 
+// DomainASCII validates a domain like Domain(), and returns it in the ASCII
+// form that's used for DNS: all labels are in lower case, and internationalized
+// labels are encoded as punycode (the IDNA ToASCII form).
+//
+// For example "Bücher.example" and "xn--bcher-kva.EXAMPLE" are both returned as
+// "xn--bcher-kva.example".
+func (v *Validator) DomainASCII(key, value string, message ...string) string {
+	labels := v.Domain(key, value, message...)
+	if labels == nil {
+		return ""
+	}
+
+	for i, l := range labels {
+		l = strings.ToLower(l)
+		if !isASCII(l) {
+			enc, err := punyEncode(l)
+			if err != nil { // Should never happen, as validDomain() already checks this.
+				v.appendMessage(key, "domain", message)
+				return ""
+			}
+			l = "xn--" + enc
+		}
+		labels[i] = l
+	}
+	return strings.Join(labels, ".")
+}
+
 // Hostname checks if this is a valid hostname.
 //
 // This is different from Domain in that it considers any hostname valid,
@@ -255,14 +532,73 @@ func (v *Validator) Hostname(key, value string, message ...string) []string {
 		return nil
 	}
 
-	msg := getMessage(message, MessageHostname)
+	msg := getMessage(message, v.message("hostname"))
 	labels, err := validDomain(value, 1)
 	if err != nil {
-		v.Append(key, fmt.Sprintf("%s: %s", msg, err))
+		v.AppendCode(key, "hostname", fmt.Sprintf("%s: %s", msg, err))
 	}
 	return labels
 }
 
+// Host validates that this is either a hostname as with Hostname(), or an IPv4
+// or IPv6 address.
+//
+// Returns the host in a normalized form: IP addresses are formatted with
+// net.IP.String() and hostnames are in lower case without a trailing ".". An
+// IPv6 address may be enclosed in [ and ].
+func (v *Validator) Host(key, value string, message ...string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	host, ok := normalizeHost(value)
+	if !ok {
+		v.appendMessage(key, "host", message)
+	}
+	return host
+}
+
+// HostPort validates that this is a host as with Host() and a port number,
+// such as "example.com:8080" or "[::1]:8080", and returns both parts.
+//
+// The port must be between 1 and 65535.
+func (v *Validator) HostPort(key, value string, message ...string) (string, int) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", 0
+	}
+
+	h, p, err := net.SplitHostPort(value)
+	if err != nil {
+		v.appendMessage(key, "host_port", message)
+		return "", 0
+	}
+	host, ok := normalizeHost(h)
+	port, err := strconv.Atoi(p)
+	if !ok || err != nil || port < 1 || port > 65535 {
+		v.appendMessage(key, "host_port", message)
+		return "", 0
+	}
+	return host, port
+}
+
+// normalizeHost normalizes a hostname or IP address, as described in Host().
+func normalizeHost(value string) (string, bool) {
+	ipStr := value
+	if len(ipStr) > 2 && ipStr[0] == '[' && ipStr[len(ipStr)-1] == ']' {
+		ipStr = ipStr[1 : len(ipStr)-1]
+	}
+	if ip := net.ParseIP(ipStr); ip != nil {
+		return ip.String(), true
+	}
+
+	if _, err := validDomain(value, 1); err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.ToLower(value), "."), true
+}
+
 func validDomain(value string, minLabels int) ([]string, error) {
 	if len(value) < 3 || value[0] == '.' {
 		return nil, fmt.Errorf("too short")
@@ -276,18 +612,23 @@ func validDomain(value string, minLabels int) ([]string, error) {
 		return nil, fmt.Errorf("need at least %d labels", minLabels)
 	}
 
-	var total int
+	// Total length includes the dots between labels.
+	total := len(labels) - 1
 	for i, l := range labels {
 		// See RFC 1034, section 3.1, RFC 1035, secion 2.3.1
 		//
 		// - Only allow letters, numbers
 		// - Max size of a single label is 63 bytes
+		// - Max size of the full domain is 253 bytes
 		// - Need at least two labels
-		if len(l) > 63 {
-			return nil, errors.New("label is longer than 63 bytes")
+		// - Labels can't start or end with a hyphen
+		//
+		// The lengths are for the ASCII (punycode) form.
+		if l == "" {
+			return nil, errors.New("empty label")
 		}
-		total += len(l)
 
+		ascii := l
 		if strings.HasPrefix(l, "xn--") {
 			var err error
 			l, err = punyDecode(l[4:])
@@ -295,8 +636,22 @@ func validDomain(value string, minLabels int) ([]string, error) {
 				return nil, fmt.Errorf("not valid punycode: %q", l)
 			}
 			labels[i] = l
+		} else if !isASCII(l) {
+			enc, err := punyEncode(strings.ToLower(l))
+			if err != nil {
+				return nil, fmt.Errorf("can't encode as punycode: %q", l)
+			}
+			ascii = "xn--" + enc
+		}
+
+		if len(ascii) > 63 {
+			return nil, errors.New("label is longer than 63 bytes")
 		}
+		total += len(ascii)
 
+		if l == "" || l[0] == '-' || l[len(l)-1] == '-' {
+			return nil, errors.New("label can't start or end with a hyphen")
+		}
 		for _, c := range l {
 			if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '-' {
 				return nil, fmt.Errorf("invalid character: %q", c)
@@ -304,13 +659,22 @@ func validDomain(value string, minLabels int) ([]string, error) {
 		}
 	}
 
-	if total > 255 {
-		return nil, fmt.Errorf("domain is longer than 255 bytes")
+	if total > 253 {
+		return nil, fmt.Errorf("domain is longer than 253 bytes")
 	}
 
 	return labels, nil
 }
 
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
 // URL parses an URL.
 //
 // The URL may consist of a scheme, host, path, and query parameters. Only the
@@ -319,94 +683,382 @@ func validDomain(value string, minLabels int) ([]string, error) {
 // Local URLs are not considered valid; the host needs to have at least two
 // labels. Use URLLocal() if you also want to accept e.g "http://localhost".
 //
+// IP addresses are accepted as the host, such as "http://192.168.1.10:8080" or
+// "http://[::1]/x". Use URLNoIP() to reject them.
+//
 // If the scheme is not given "http" will be prepended.
 func (v *Validator) URL(key, value string, message ...string) *url.URL {
-	return v.url(key, value, false, message...)
+	return v.url(key, value, urlOptions{}, message...)
 }
 
 // URLLocal is like URL, but also considers local URLs to be valid.
 func (v *Validator) URLLocal(key, value string, message ...string) *url.URL {
-	return v.url(key, value, true, message...)
+	return v.url(key, value, urlOptions{local: true}, message...)
 }
 
-func (v *Validator) url(key, value string, local bool, message ...string) *url.URL {
-	if value == "" {
-		return nil
-	}
+// URLNoIP is like URL, but doesn't accept IP addresses as the host.
+func (v *Validator) URLNoIP(key, value string, message ...string) *url.URL {
+	return v.url(key, value, urlOptions{noIP: true}, message...)
+}
 
-	msg := getMessage(message, MessageURL)
+// URLPublic is like URL, but doesn't accept private, loopback, or link-local IP
+// addresses as the host, such as "http://169.254.169.254/".
+//
+// This is a guard against obvious SSRF targets when accepting URLs for webhooks
+// and the like. Only IP addresses in the URL are checked: hostnames aren't
+// resolved, and may still resolve to a private address.
+func (v *Validator) URLPublic(key, value string, message ...string) *url.URL {
+	return v.url(key, value, urlOptions{publicIP: true}, message...)
+}
 
-	u, err := url.Parse(value)
-	if err != nil && u == nil {
-		v.Append(key, "%s: %s", msg, err)
+// URLPath is like URL, but also requires a path other than "/"; for example
+// "https://example.com/hook" is valid, but "https://example.com" is not.
+func (v *Validator) URLPath(key, value string, message ...string) *url.URL {
+	u := v.url(key, value, urlOptions{}, message...)
+	if u == nil {
 		return nil
 	}
+	if u.Path == "" || u.Path == "/" {
+		v.appendMessage(key, "url_path", message)
+		return nil
+	}
+	return u
+}
 
-	// If we don't have a scheme the parse may or may not fail according to the
-	// go docs. "Trying to parse a hostname and path without a scheme is invalid
-	// but may not necessarily return an error, due to parsing ambiguities."
-	if u.Scheme == "" {
-		u.Scheme = "http"
-		u, err = url.Parse(u.String())
+// URLRequireScheme is like URL, but requires that the scheme is given
+// explicitly and is one of schemes, instead of prepending "http". Any scheme is
+// allowed if schemes is empty.
+//
+// For example with []string{"https"} "https://example.com/hook" is valid, but
+// "example.com/hook" and "http://example.com/hook" are not.
+func (v *Validator) URLRequireScheme(key, value string, schemes []string, message ...string) *url.URL {
+	if value == "" {
+		return nil
 	}
 
+	u, err := url.Parse(value)
 	if err != nil {
-		v.Append(key, "%s: %s", msg, err)
+		v.AppendCode(key, "url", "%s: %s", getMessage(message, v.message("url")), err)
 		return nil
 	}
 
-	if u.Host == "" {
-		v.Append(key, msg)
+	// The scheme must be at the start; "example.com/x?r=http://a" doesn't have
+	// one.
+	n := len(u.Scheme) + 3
+	hasScheme := u.Scheme != "" && len(value) > n && strings.EqualFold(value[:n], u.Scheme+"://")
+	if !hasScheme || (len(schemes) > 0 && !containsFold(u.Scheme, schemes)) {
+		if len(schemes) == 0 {
+			v.appendMessage(key, "url_scheme", message, "a scheme")
+		} else {
+			v.appendParams(key, "url_scheme", map[string]interface{}{"schemes": schemes}, message, strings.Join(schemes, ":// or ")+"://")
+		}
 		return nil
 	}
 
-	host := u.Host
-	if h, _, err := net.SplitHostPort(u.Host); err == nil {
-		host = h
+	return v.url(key, value, urlOptions{}, message...)
+}
+
+func containsFold(s string, list []string) bool {
+	for _, l := range list {
+		if strings.EqualFold(s, l) {
+			return true
+		}
 	}
+	return false
+}
 
-	_, err = validDomain(host, map[bool]int{true: 1, false: 2}[local])
-	if err != nil {
-		v.Append(key, msg)
+// URLNormalized is like URL, but returns a normalized URL so that equivalent
+// URLs are the same when stored or compared:
+//
+//   - the scheme and host are in lower case;
+//   - the default port is removed (80 for http, 443 for https);
+//   - "." and ".." are removed from the path, and an empty path becomes "/";
+//   - an empty query ("?") or fragment ("#") is removed.
+//
+// For example "HTTP://Example.COM:80/./a/../b" is returned as
+// "http://example.com/b".
+func (v *Validator) URLNormalized(key, value string, message ...string) *url.URL {
+	u := v.url(key, value, urlOptions{}, message...)
+	if u == nil {
 		return nil
 	}
 
-	return u
-}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "http" && u.Port() == "80") || (u.Scheme == "https" && u.Port() == "443") {
+		u.Host = u.Host[:strings.LastIndexByte(u.Host, ':')]
+	}
+
+	p := removeDotSegments(u.EscapedPath())
+	if p == "" {
+		p = "/"
+	}
+	if up, err := url.PathUnescape(p); err == nil {
+		u.Path, u.RawPath = up, p
+	}
+	u.ForceQuery = false
+	return u
+}
+
+// removeDotSegments removes "." and ".." segments from a path, as described in
+// RFC 3986 section 5.2.4.
+func removeDotSegments(p string) string {
+	if !strings.Contains(p, ".") {
+		return p
+	}
+
+	var (
+		in  = strings.Split(p, "/")
+		out = make([]string, 0, len(in))
+	)
+	for i, seg := range in {
+		last := i == len(in)-1
+		switch seg {
+		case ".":
+			if last {
+				out = append(out, "")
+			}
+		case "..":
+			if len(out) > 1 {
+				out = out[:len(out)-1]
+			}
+			if last {
+				out = append(out, "")
+			}
+		default:
+			out = append(out, seg)
+		}
+	}
+	return strings.Join(out, "/")
+}
+
+// QueryString validates that the value is a valid URL query string, such as
+// "utm_source=x&utm_medium=y", and returns the parsed values. A leading "?" is
+// stripped.
+//
+// Semicolons are never accepted as a separator.
+func (v *Validator) QueryString(key, value string, message ...string) url.Values {
+	return v.QueryStringLimit(key, value, 0, 0, message...)
+}
+
+// QueryStringLimit is like QueryString, but also limits the number of
+// parameters and the length of the value (in bytes). Use 0 for no limit.
+//
+// The length is checked before parsing.
+func (v *Validator) QueryStringLimit(key, value string, maxKeys, maxLen int, message ...string) url.Values {
+	value = strings.TrimPrefix(value, "?")
+	if value == "" {
+		return nil
+	}
+
+	if maxLen > 0 && len(value) > maxLen {
+		v.appendParams(key, "len_shorter", map[string]interface{}{"max": maxLen}, message, maxLen)
+		return nil
+	}
+
+	q, err := url.ParseQuery(value)
+	if err != nil || len(q) == 0 || strings.ContainsRune(value, ';') {
+		v.appendMessage(key, "query_string", message)
+		return nil
+	}
+
+	if maxKeys > 0 && len(q) > maxKeys {
+		v.appendParams(key, "query_string_keys", map[string]interface{}{"max": maxKeys}, message, maxKeys)
+		return nil
+	}
+	return q
+}
+
+// FileExt validates that the extension of filename is one of the allowed
+// extensions, and returns the extension in lower case without the leading ".".
+//
+// Extensions are compared case-insensitive and may contain a dot, such as
+// "tar.gz"; a leading "." in allowed is ignored.
+func (v *Validator) FileExt(key, filename string, allowed []string, message ...string) string {
+	if filename == "" {
+		return ""
+	}
+
+	name := strings.ToLower(filename)
+	if i := strings.LastIndexAny(name, `/\`); i > -1 {
+		name = name[i+1:]
+	}
+	for _, a := range allowed {
+		a = strings.ToLower(strings.TrimPrefix(a, "."))
+		if a != "" && len(name) > len(a)+1 && strings.HasSuffix(name, "."+a) {
+			return a
+		}
+	}
+
+	v.appendParams(key, "file_ext", map[string]interface{}{"values": allowed}, message, strings.Join(allowed, ", "))
+	return ""
+}
+
+type urlOptions struct {
+	local    bool // Allow hosts with one label, such as "localhost".
+	noIP     bool // Don't allow IP addresses as the host.
+	publicIP bool // Only allow public IP addresses as the host.
+}
+
+func (v *Validator) url(key, value string, opts urlOptions, message ...string) *url.URL {
+	if value == "" {
+		return nil
+	}
+
+	msg := getMessage(message, v.message("url"))
+
+	u, err := url.Parse(value)
+
+	// "host:port/path" without a scheme is either an error or parsed as the
+	// scheme "host"; try again with a scheme.
+	if (err != nil || (u.Host == "" && u.Opaque != "")) && !strings.Contains(value, "://") {
+		if u2, err2 := url.Parse("http://" + value); err2 == nil && u2.Host != "" {
+			u, err = u2, nil
+		}
+	}
+
+	if err != nil && u == nil {
+		v.AppendCode(key, "url", "%s: %s", msg, err)
+		return nil
+	}
+
+	// If we don't have a scheme the parse may or may not fail according to the
+	// go docs. "Trying to parse a hostname and path without a scheme is invalid
+	// but may not necessarily return an error, due to parsing ambiguities."
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		u, err = url.Parse(u.String())
+	}
+
+	if err != nil {
+		v.AppendCode(key, "url", "%s: %s", msg, err)
+		return nil
+	}
+
+	if u.Host == "" {
+		v.AppendCode(key, "url", msg)
+		return nil
+	}
+
+	host := u.Hostname()
+	ip := net.ParseIP(host)
+	if ip == nil && opts.publicIP {
+		// Many resolvers also accept forms such as "127.1" or "0x7f.0.0.1".
+		ip = parseIPv4Loose(host)
+	}
+	if ip != nil {
+		if opts.noIP {
+			v.appendMessage(key, "url_no_ip", message)
+			return nil
+		}
+		if opts.publicIP && !isPublicIP(ip) {
+			v.appendMessage(key, "public_ip", message)
+			return nil
+		}
+		return u
+	}
+	// No TLD starts with a digit; this is probably an IP address in a form we
+	// don't understand, so don't risk it.
+	if opts.publicIP && host != "" {
+		labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+		if l := labels[len(labels)-1]; l != "" && l[0] >= '0' && l[0] <= '9' {
+			v.appendMessage(key, "public_ip", message)
+			return nil
+		}
+	}
+
+	// A single label with only digits isn't a hostname: most resolvers treat
+	// "1234" as the IP address 0.0.4.210.
+	if l := strings.TrimSuffix(host, "."); opts.local && !strings.Contains(l, ".") && isDigit(l) {
+		v.AppendCode(key, "url", msg)
+		return nil
+	}
+
+	_, err = validDomain(host, map[bool]int{true: 1, false: 2}[opts.local])
+	if err != nil {
+		v.AppendCode(key, "url", msg)
+		return nil
+	}
+
+	return u
+}
 
 // Email parses an email address.
+//
+// The address is returned as given; use EmailNormalized() to get the domain in
+// lower case.
 func (v *Validator) Email(key, value string, message ...string) mail.Address {
 	if value == "" {
 		return mail.Address{}
 	}
 
-	msg := getMessage(message, MessageEmail)
+	msg := getMessage(message, v.message("email"))
 	addr, err := mail.ParseAddress(value)
 	if err != nil {
-		v.Append(key, msg)
+		v.AppendCode(key, "email", msg)
 		return mail.Address{}
 	}
 
 	// "foo@domain" is technically valid, but practically never what's intended.
 	_, err = validDomain(addr.Address[strings.LastIndex(addr.Address, "@")+1:], 2)
 	if err != nil {
-		v.Append(key, msg)
+		v.AppendCode(key, "email", msg)
 		return mail.Address{}
 	}
 
 	return *addr
 }
 
+// EmailNormalized is like Email(), but returns the address with the domain in
+// lower case, so that "user@EXAMPLE.COM" and "user@example.com" are the same.
+//
+// The local part (before the @) is case-sensitive according to RFC 5321, so its
+// case is preserved unless lowerLocal is set. Almost all mail servers treat it
+// as case-insensitive though.
+func (v *Validator) EmailNormalized(key, value string, lowerLocal bool, message ...string) mail.Address {
+	addr := v.Email(key, value, message...)
+	if addr.Address == "" {
+		return addr
+	}
+
+	at := strings.LastIndex(addr.Address, "@")
+	local, domain := addr.Address[:at], addr.Address[at+1:]
+	if lowerLocal {
+		local = strings.ToLower(local)
+	}
+	addr.Address = local + "@" + strings.ToLower(domain)
+	return addr
+}
+
 // IPv4 parses an IPv4 address.
 func (v *Validator) IPv4(key, value string, message ...string) net.IP {
 	if value == "" {
 		return net.IP{}
 	}
 
-	msg := getMessage(message, MessageIPv4)
+	msg := getMessage(message, v.message("ipv4"))
 	ip := net.ParseIP(value)
 	if ip == nil || ip.To4() == nil {
-		v.Append(key, msg)
+		v.AppendCode(key, "ipv4", msg)
+	}
+	return ip
+}
+
+// IPv6 parses an IPv6 address.
+//
+// IPv4 addresses, including IPv4-mapped IPv6 addresses such as
+// "::ffff:127.0.0.1", are not valid.
+//
+// Returns an empty net.IP{} if the value is empty or not valid.
+func (v *Validator) IPv6(key, value string, message ...string) net.IP {
+	if value == "" {
+		return net.IP{}
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil || ip.To4() != nil {
+		v.appendMessage(key, "ipv6", message)
+		return net.IP{}
 	}
 	return ip
 }
@@ -417,24 +1069,189 @@ func (v *Validator) IP(key, value string, message ...string) net.IP {
 		return net.IP{}
 	}
 
-	msg := getMessage(message, MessageIP)
+	msg := getMessage(message, v.message("ip"))
 	ip := net.ParseIP(value)
 	if ip == nil {
-		v.Append(key, msg)
+		v.AppendCode(key, "ip", msg)
+	}
+	return ip
+}
+
+// PublicIP parses an IPv4 or IPv6 address, and validates that it's not in a
+// private, loopback, link-local, multicast, documentation, or other reserved
+// range. For IPv6 addresses that embed an IPv4 address (NAT64, 6to4, and
+// "::1.2.3.4") the IPv4 address is checked.
+//
+// This is useful to prevent SSRF when making requests to user-supplied hosts;
+// note that a hostname may still resolve to a private address, so you want to
+// check the IP that's actually connected to.
+//
+// Returns nil if the IP address isn't valid or isn't public.
+func (v *Validator) PublicIP(key, value string, message ...string) net.IP {
+	if value == "" {
+		return net.IP{}
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		v.appendMessage(key, "ip", message)
+		return nil
+	}
+	if !isPublicIP(ip) {
+		v.appendMessage(key, "public_ip", message)
+		return nil
+	}
+	return ip
+}
+
+// parseIPv4Loose parses an IPv4 address like inet_aton() does: there can be 1
+// to 4 parts which are decimal, octal ("0177"), or hex ("0x7f"), and the last
+// part fills the remaining bytes. For example "127.1" is 127.0.0.1 and
+// "0x7f000001" is 127.0.0.1.
+//
+// Returns nil if s isn't in this form.
+func parseIPv4Loose(s string) net.IP {
+	parts := strings.Split(strings.TrimSuffix(s, "."), ".")
+	if len(parts) > 4 {
+		return nil
+	}
+
+	n := make([]uint64, len(parts))
+	for i, p := range parts {
+		base := 10
+		switch {
+		case len(p) > 2 && (p[:2] == "0x" || p[:2] == "0X"):
+			p, base = p[2:], 16
+		case len(p) > 1 && p[0] == '0':
+			p, base = p[1:], 8
+		}
+		var err error
+		n[i], err = strconv.ParseUint(p, base, 32)
+		if err != nil {
+			return nil
+		}
+	}
+
+	ip := make(net.IP, 4)
+	last := len(n) - 1
+	for i := 0; i < last; i++ {
+		if n[i] > 255 {
+			return nil
+		}
+		ip[i] = byte(n[i])
+	}
+	if n[last] > 1<<(8*uint(4-last))-1 {
+		return nil
+	}
+	for i := 3; i >= last; i-- {
+		ip[i] = byte(n[last])
+		n[last] >>= 8
 	}
 	return ip
 }
 
+var privateNets = func() []*net.IPNet {
+	var n []*net.IPNet
+	for _, c := range []string{
+		"0.0.0.0/8",          // "This network".
+		"10.0.0.0/8",         // Private.
+		"100.64.0.0/10",      // Carrier-grade NAT.
+		"172.16.0.0/12",      // Private.
+		"192.0.2.0/24",       // Documentation (TEST-NET-1).
+		"192.168.0.0/16",     // Private.
+		"198.51.100.0/24",    // Documentation (TEST-NET-2).
+		"203.0.113.0/24",     // Documentation (TEST-NET-3).
+		"255.255.255.255/32", // Limited broadcast.
+		"2001:db8::/32",      // Documentation.
+		"fc00::/7",           // Unique local.
+		"fec0::/10",          // Site local (deprecated).
+	} {
+		_, nn, _ := net.ParseCIDR(c)
+		n = append(n, nn)
+	}
+	return n
+}()
+
+// IPv6 networks that embed an IPv4 address at the given offset; the IPv4
+// address is checked instead.
+type embeddedIPv4Net struct {
+	n      *net.IPNet
+	offset int
+}
+
+var embeddedIPv4Nets = func() []embeddedIPv4Net {
+	var n []embeddedIPv4Net
+	for _, c := range []struct {
+		cidr   string
+		offset int
+	}{
+		{"64:ff9b::/96", 12}, // NAT64.
+		{"2002::/16", 2},     // 6to4.
+		{"::/96", 12},        // IPv4-compatible (deprecated), e.g. "::127.0.0.1".
+	} {
+		_, nn, _ := net.ParseCIDR(c.cidr)
+		n = append(n, embeddedIPv4Net{nn, c.offset})
+	}
+	return n
+}()
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	// IPv4-mapped addresses ("::ffff:127.0.0.1") are already handled as IPv4
+	// by To4() in the net package, but the other forms aren't.
+	if ip.To4() == nil {
+		for _, e := range embeddedIPv4Nets {
+			if e.n.Contains(ip) {
+				return isPublicIP(net.IP(ip[e.offset : e.offset+4]))
+			}
+		}
+	}
+	for _, n := range privateNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IPIn validates that the IP address is in one of the networks.
+//
+// This is useful to check an already parsed IP against an allow-list:
+//
+//   _, lan, _ := net.ParseCIDR("192.168.0.0/16")
+//   v.IPIn("ip", ip, []*net.IPNet{lan})
+//
+// A nil or empty IP is valid.
+func (v *Validator) IPIn(key string, ip net.IP, networks []*net.IPNet, message ...string) {
+	if len(ip) == 0 {
+		return
+	}
+
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return
+		}
+	}
+
+	l := make([]string, len(networks))
+	for i := range networks {
+		l[i] = networks[i].String()
+	}
+	v.appendParams(key, "ip_in", map[string]interface{}{"networks": l}, message, strings.Join(l, ", "))
+}
+
 // HexColor parses a color as a hex triplet (e.g. #ffffff or #fff).
 func (v *Validator) HexColor(key, value string, message ...string) (uint8, uint8, uint8) {
 	if value == "" {
 		return 0, 0, 0
 	}
 
-	msg := getMessage(message, MessageHexColor)
+	msg := getMessage(message, v.message("hex_color"))
 
 	if value[0] != '#' {
-		v.Append(key, msg)
+		v.AppendCode(key, "hex_color", msg)
 		return 0, 0, 0
 	}
 
@@ -448,13 +1265,156 @@ func (v *Validator) HexColor(key, value string, message ...string) (uint8, uint8
 
 	n, err := fmt.Sscanf(strings.ToLower(value), "#%x", &rgb)
 	if n != 1 || len(rgb) != 3 || err != nil {
-		v.Append(key, msg)
+		v.AppendCode(key, "hex_color", msg)
 		return 0, 0, 0
 	}
 
 	return rgb[0], rgb[1], rgb[2]
 }
 
+// ColorFunc parses a color in the CSS functional notation: rgb(), rgba(),
+// hsl(), or hsla().
+//
+// Both the comma-separated syntax ("rgb(255, 0, 0)", "hsla(120, 50%, 50%,
+// 0.5)") and the space-separated syntax ("rgb(255 0 0 / 50%)") are accepted.
+// The RGB components can be numbers from 0 to 255 or percentages, the hue is
+// from 0 to 360, the saturation and lightness are percentages, and the alpha is
+// a number from 0 to 1 or a percentage.
+//
+// Returns the color as red, green, blue, and alpha values; the alpha is 255 if
+// it's not given.
+func (v *Validator) ColorFunc(key, value string, message ...string) (uint8, uint8, uint8, uint8) {
+	if value == "" {
+		return 0, 0, 0, 0
+	}
+
+	msg := getMessage(message, "")
+	r, g, b, a, err := v.parseColorFunc(value)
+	if err != nil {
+		if msg != "" {
+			v.AppendCode(key, "color_func", msg)
+		} else {
+			v.AppendCode(key, "color_func", "%s", err)
+		}
+		return 0, 0, 0, 0
+	}
+	return r, g, b, a
+}
+
+func (v *Validator) parseColorFunc(value string) (uint8, uint8, uint8, uint8, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	open := strings.IndexByte(value, '(')
+	if open == -1 || value[len(value)-1] != ')' {
+		return 0, 0, 0, 0, errors.New(v.message("color_func"))
+	}
+
+	name, args := strings.TrimSpace(value[:open]), value[open+1:len(value)-1]
+	if name != "rgb" && name != "rgba" && name != "hsl" && name != "hsla" {
+		return 0, 0, 0, 0, errors.New(v.message("color_func"))
+	}
+
+	// "rgb(1, 2, 3, 0.5)" or "rgb(1 2 3 / 0.5)"
+	var comp []string
+	alpha := "1"
+	if strings.Contains(args, ",") {
+		comp = strings.Split(args, ",")
+		for i := range comp {
+			comp[i] = strings.TrimSpace(comp[i])
+		}
+		if len(comp) == 4 {
+			alpha, comp = comp[3], comp[:3]
+		}
+	} else {
+		a := strings.Split(args, "/")
+		if len(a) > 2 {
+			return 0, 0, 0, 0, errors.New(v.message("color_func"))
+		}
+		if len(a) == 2 {
+			alpha = strings.TrimSpace(a[1])
+		}
+		comp = strings.Fields(a[0])
+	}
+	if len(comp) != 3 {
+		return 0, 0, 0, 0, errors.New(v.message("color_func"))
+	}
+
+	var rgb [3]float64
+	if name[0] == 'r' {
+		for i, n := range []string{"red", "green", "blue"} {
+			f, pct, ok := parseColorNumber(comp[i])
+			if !ok {
+				return 0, 0, 0, 0, errors.New(v.message("color_func"))
+			}
+			if pct {
+				if f < 0 || f > 100 {
+					return 0, 0, 0, 0, fmt.Errorf(v.message("color_range"), n, "0-100%")
+				}
+				f = f * 255 / 100
+			} else if f < 0 || f > 255 {
+				return 0, 0, 0, 0, fmt.Errorf(v.message("color_range"), n, "0-255")
+			}
+			rgb[i] = f
+		}
+	} else {
+		h, pct, ok := parseColorNumber(strings.TrimSuffix(comp[0], "deg"))
+		if !ok || pct {
+			return 0, 0, 0, 0, errors.New(v.message("color_func"))
+		}
+		if h < 0 || h > 360 {
+			return 0, 0, 0, 0, fmt.Errorf(v.message("color_range"), "hue", "0-360")
+		}
+
+		var sl [2]float64
+		for i, n := range []string{"saturation", "lightness"} {
+			f, _, ok := parseColorNumber(comp[i+1])
+			if !ok {
+				return 0, 0, 0, 0, errors.New(v.message("color_func"))
+			}
+			if f < 0 || f > 100 {
+				return 0, 0, 0, 0, fmt.Errorf(v.message("color_range"), n, "0-100%")
+			}
+			sl[i] = f / 100
+		}
+		rgb = hslToRGB(h, sl[0], sl[1])
+	}
+
+	a, pct, ok := parseColorNumber(alpha)
+	if !ok {
+		return 0, 0, 0, 0, errors.New(v.message("color_func"))
+	}
+	if pct {
+		a /= 100
+	}
+	if a < 0 || a > 1 {
+		return 0, 0, 0, 0, fmt.Errorf(v.message("color_range"), "alpha", "0-1")
+	}
+
+	return uint8(math.Round(rgb[0])), uint8(math.Round(rgb[1])),
+		uint8(math.Round(rgb[2])), uint8(math.Round(a * 255)), nil
+}
+
+func parseColorNumber(s string) (float64, bool, bool) {
+	pct := strings.HasSuffix(s, "%")
+	if pct {
+		s = s[:len(s)-1]
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false, false
+	}
+	return f, pct, true
+}
+
+// See https://www.w3.org/TR/css-color-4/#hsl-to-rgb
+func hslToRGB(h, s, l float64) [3]float64 {
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return (l - a*math.Max(-1, math.Min(k-3, math.Min(9-k, 1)))) * 255
+	}
+	return [3]float64{f(0), f(8), f(4)}
+}
+
 // UTF8 validates that this string is valid UTF-8.
 //
 // Caveat: this will consider NULL bytes *invalid* even though they're valid in
@@ -463,10 +1423,30 @@ func (v *Validator) HexColor(key, value string, message ...string) (uint8, uint8
 // people trying to insert exploits. So the practical thing to do is just to
 // reject it.
 func (v *Validator) UTF8(key, value string, message ...string) {
-	msg := getMessage(message, MessageUTF8)
+	msg := getMessage(message, v.message("utf8"))
 	if !validString(value) {
-		v.Append(key, msg)
+		v.AppendCode(key, "utf8", msg)
+	}
+}
+
+// NormalizedNFC validates that the value is in Unicode Normalization Form C,
+// and returns the normalized value.
+//
+// Text can be written in different ways which look identical, such as "é" as a
+// single character or as "e" followed by a combining accent; this can be used
+// to prevent e.g. usernames which look the same. The returned value can be
+// stored even if there's an error:
+//
+//   name := v.NormalizedNFC("name", name)
+//   v.Pop("name") // Don't report an error; just store the normalized form.
+//
+// Invalid UTF-8 is replaced with U+FFFD; use UTF8() to check for it.
+func (v *Validator) NormalizedNFC(key, value string, message ...string) string {
+	n := normalizeNFC(value)
+	if n != value {
+		v.appendMessage(key, "nfc", message)
 	}
+	return n
 }
 
 // Contains validates that this string only contains the given characters.
@@ -490,7 +1470,7 @@ func (v *Validator) UTF8(key, value string, message ...string) {
 //   unicode.ASCII_Hex_Digit   0-9A-Fa-f
 func (v *Validator) Contains(key, value string, ranges []*unicode.RangeTable, runes []rune, message ...string) {
 	if !validString(value) {
-		v.Append(key, getMessage(message, MessageUTF8))
+		v.appendMessage(key, "utf8", message)
 	}
 
 	var invalid []rune
@@ -507,7 +1487,7 @@ func (v *Validator) Contains(key, value string, ranges []*unicode.RangeTable, ru
 		for i := range invalid {
 			cannot[i] = fmt.Sprintf("%q", invalid[i])
 		}
-		v.Append(key, fmt.Sprintf(getMessage(message, MessageContains), strings.Join(cannot, ", ")))
+		v.AppendCode(key, "contains", fmt.Sprintf(getMessage(message, v.message("contains")), strings.Join(cannot, ", ")))
 	}
 }
 
@@ -535,41 +1515,426 @@ func containsAnyRune(r rune, runes []rune) bool {
 	return false
 }
 
-// Len validates the character (rune) length of a string.
+// NoEmoji validates that the value doesn't contain any emoji.
 //
-// A maximum of 0 indicates there is no upper limit.
-func (v *Validator) Len(key, value string, min, max int, message ...string) int {
-	msg := getMessage(message, "")
-
-	l := utf8.RuneCountInString(value)
-	switch {
-	case l < min:
-		if msg != "" {
-			v.Append(key, msg)
-		} else {
-			v.Append(key, fmt.Sprintf(MessageLenLonger, min))
-		}
-	case max > 0 && l > max:
-		if msg != "" {
-			v.Append(key, msg)
-		} else {
-			v.Append(key, fmt.Sprintf(MessageLenShorter, max))
+// This rejects characters that are displayed as emoji by default, regional
+// indicators (flags), skin tone modifiers, keycaps, and characters followed by
+// the emoji variation selector (U+FE0F), which includes all emoji ZWJ
+// sequences. Symbols such as "☺" or "©" which are displayed as text by default
+// are allowed.
+func (v *Validator) NoEmoji(key, value string, message ...string) {
+	for _, r := range value {
+		if isEmoji(r) {
+			v.appendMessage(key, "no_emoji", message)
+			return
 		}
 	}
-	return l
 }
 
-// Integer parses a string as an integer.
-func (v *Validator) Integer(key, value string, message ...string) int64 {
-	if value == "" {
-		return 0
-	}
-
-	i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
-	if err != nil {
-		v.Append(key, getMessage(message, MessageInteger))
+// Printable validates that the value doesn't contain control characters or
+// invisible zero-width characters, which are often the result of copy/paste.
+//
+// Tabs and newlines are allowed; use SingleLine() to disallow newlines. The
+// zero-width joiner and non-joiner (U+200C, U+200D) are also allowed, as they're
+// required to write some languages correctly.
+func (v *Validator) Printable(key, value string, message ...string) {
+	for _, r := range value {
+		if isInvisible(r) {
+			v.appendMessage(key, "printable", message)
+			return
+		}
 	}
-	return i
+}
+
+func isInvisible(r rune) bool {
+	switch r {
+	case '\t', '\n', '\r':
+		return false
+	case
+		0x00ad, // SOFT HYPHEN
+		0x180e, // MONGOLIAN VOWEL SEPARATOR
+		0x200b, // ZERO WIDTH SPACE
+		0x2060, // WORD JOINER
+		0xfeff: // ZERO WIDTH NO-BREAK SPACE (byte order mark)
+		return true
+	}
+	return unicode.IsControl(r)
+}
+
+// NoSpace validates that the value doesn't start or end with whitespace.
+//
+// This is useful for identifiers and codes, where a trailing space from
+// copy/paste would give a subtly different value; use strings.TrimSpace() if
+// you want to accept it instead.
+func (v *Validator) NoSpace(key, value string, message ...string) {
+	if value != strings.TrimSpace(value) {
+		v.appendMessage(key, "no_space", message)
+	}
+}
+
+// Len validates the character (rune) length of a string.
+//
+// A maximum of 0 indicates there is no upper limit.
+func (v *Validator) Len(key, value string, min, max int, message ...string) int {
+	l := utf8.RuneCountInString(value)
+	switch {
+	case l < min:
+		v.appendParams(key, "len_longer", map[string]interface{}{"min": min}, message, min)
+	case max > 0 && l > max:
+		v.appendParams(key, "len_shorter", map[string]interface{}{"max": max}, message, max)
+	}
+	return l
+}
+
+// RequiredLen is like Len(), but the value must also be set.
+//
+// An empty value or a value with only whitespace adds just the Required()
+// error, instead of both "must be set" and "must be longer than".
+func (v *Validator) RequiredLen(key, value string, min, max int, message ...string) int {
+	if strings.TrimSpace(value) == "" {
+		v.appendMessage(key, "required", message)
+		return 0
+	}
+	return v.Len(key, value, min, max, message...)
+}
+
+// Words validates the number of words in a string, and returns the number of
+// words.
+//
+// Words are separated by whitespace, as with strings.Fields(). A maximum of 0
+// indicates there is no upper limit.
+func (v *Validator) Words(key, value string, min, max int, message ...string) int {
+	l := len(strings.Fields(value))
+	switch {
+	case l < min:
+		v.appendParams(key, "words_longer", map[string]interface{}{"min": min}, message, min)
+	case max > 0 && l > max:
+		v.appendParams(key, "words_shorter", map[string]interface{}{"max": max}, message, max)
+	}
+	return l
+}
+
+// LenGraphemes validates the length of a string in user-perceived characters
+// (extended grapheme clusters), and returns the length.
+//
+// This is like Len(), but "👩‍👩‍👧‍👦" or "é" written with a combining accent are
+// counted as one character rather than as several runes, which is usually what
+// is meant with "display name can be at most 20 characters".
+func (v *Validator) LenGraphemes(key, value string, min, max int, message ...string) int {
+	l := graphemeCount(value)
+	switch {
+	case l < min:
+		v.appendParams(key, "len_longer", map[string]interface{}{"min": min}, message, min)
+	case max > 0 && l > max:
+		v.appendParams(key, "len_shorter", map[string]interface{}{"max": max}, message, max)
+	}
+	return l
+}
+
+// LenSlice validates the number of items in a slice, array, or map, and returns
+// the number of items.
+//
+// This is like Len(), but for collections; a maximum of 0 indicates there is no
+// upper limit. It will panic if the value is not a slice, array, or map.
+func (v *Validator) LenSlice(key string, value interface{}, min, max int, message ...string) int {
+	var l int
+	if value != nil {
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			l = rv.Len()
+		default:
+			panic(fmt.Sprintf("zvalidate.LenSlice: not a slice, array, or map: %T", value))
+		}
+	}
+
+	switch {
+	case l < min:
+		v.appendParams(key, "len_longer", map[string]interface{}{"min": min}, message, min)
+	case max > 0 && l > max:
+		v.appendParams(key, "len_shorter", map[string]interface{}{"max": max}, message, max)
+	}
+	return l
+}
+
+// SliceLen validates the number of items in a slice, map, or anything else
+// with a length.
+//
+// This accepts the length rather than the value, for example:
+//
+//   v.SliceLen("rows", len(rows), 1, 500)
+//
+// A maximum of 0 indicates there is no upper limit.
+func (v *Validator) SliceLen(key string, length, min, max int, message ...string) {
+	switch {
+	case length < min:
+		v.appendParams(key, "slice_len_min", map[string]interface{}{"min": min}, message, min)
+	case max > 0 && length > max:
+		v.appendParams(key, "slice_len_max", map[string]interface{}{"max": max}, message, max)
+	}
+}
+
+// Integer parses a string as an integer.
+func (v *Validator) Integer(key, value string, message ...string) int64 {
+	if value == "" {
+		return 0
+	}
+
+	i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		v.appendMessage(key, "integer", message)
+	}
+	return i
+}
+
+// IntegerLoose is like Integer, but also accepts numbers with thousands
+// separators, such as "1,000", "1 000", or "1'000".
+//
+// The separator must be used consistently and every group must have 3 digits,
+// so "1,00" and "1,000 000" are invalid. A "." is never accepted as a
+// separator, as it's ambiguous with a decimal separator.
+func (v *Validator) IntegerLoose(key, value string, message ...string) int64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	num := value
+	if i := strings.IndexAny(num, ", '_\u00a0\u202f"); i > -1 {
+		var (
+			sep, _ = utf8.DecodeRuneInString(num[i:])
+			groups = strings.Split(num, string(sep))
+			first  = strings.TrimLeft(groups[0], "+-")
+		)
+		if len(first) == 0 || len(first) > 3 {
+			v.appendMessage(key, "integer", message)
+			return 0
+		}
+		for _, g := range groups[1:] {
+			if len(g) != 3 {
+				v.appendMessage(key, "integer", message)
+				return 0
+			}
+		}
+		num = strings.Join(groups, "")
+	}
+
+	i, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		v.appendMessage(key, "integer", message)
+		return 0
+	}
+	return i
+}
+
+// PositiveInteger parses a string as an integer that is higher than 0, such as
+// an ID or a count.
+//
+// This is like Integer() and Positive() combined, but with only one error.
+func (v *Validator) PositiveInteger(key, value string, message ...string) int64 {
+	if value == "" {
+		return 0
+	}
+
+	i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		v.appendMessage(key, "integer", message)
+		return 0
+	}
+	if i <= 0 {
+		v.appendMessage(key, "positive", message)
+		return 0
+	}
+	return i
+}
+
+// Numeric validates that the value contains only the digits 0-9, and returns
+// it unchanged.
+//
+// Unlike Integer(), this doesn't parse the value, so leading zeros are preserved
+// and there is no limit on the length; this is useful for things like account
+// numbers.
+func (v *Validator) Numeric(key, value string, message ...string) string {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			v.appendMessage(key, "numeric", message)
+			return ""
+		}
+	}
+	return value
+}
+
+// OTP validates a numeric one-time code with exactly digits digits, such as
+// a 2FA or email verification code. It may contain one space or hyphen between
+// two digits, as in "123 456" or "123-456".
+//
+// Returns the code without whitespace or separators, such as "123456".
+//
+// This will panic if digits is 0 or lower.
+func (v *Validator) OTP(key, value string, digits int, message ...string) string {
+	if digits <= 0 {
+		panic(fmt.Sprintf("zvalidate.OTP: digits must be higher than 0: %d", digits))
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	// Only remove a separator between two digits, so that "123456-" or
+	// "123 -456" aren't accepted.
+	if i := strings.IndexAny(value, " -"); i > 0 && i < len(value)-1 &&
+		isDigit(value[i-1:i]) && isDigit(value[i+1:i+2]) {
+		value = value[:i] + value[i+1:]
+	}
+	if len(value) != digits || !isDigit(value) {
+		v.appendParams(key, "otp", map[string]interface{}{"digits": digits}, message, digits)
+		return ""
+	}
+	return value
+}
+
+// PIN validates that this is a PIN code with exactly length digits, and
+// returns it.
+//
+// This will panic if length is 0 or lower.
+func (v *Validator) PIN(key, value string, length int, message ...string) string {
+	return v.pin(key, value, length, false, message...)
+}
+
+// PINStrong is like PIN, but also rejects weak PIN codes: the same digit
+// repeated ("1111"), ascending or descending sequences ("1234", "9876"), and
+// some other commonly used ones ("1212", "2580"). Every reason has its own
+// message.
+func (v *Validator) PINStrong(key, value string, length int, message ...string) string {
+	return v.pin(key, value, length, true, message...)
+}
+
+// Common PIN codes that aren't repeated digits or a sequence.
+var weakPINs = map[string]struct{}{
+	"1212": {}, "2580": {}, "0852": {}, "1122": {}, "1313": {}, "1010": {},
+	"2000": {}, "2001": {}, "1004": {}, "6969": {}, "5683": {},
+	"121212": {}, "123123": {}, "112233": {}, "159753": {}, "147258": {},
+	"696969": {}, "131313": {},
+}
+
+func (v *Validator) pin(key, value string, length int, strong bool, message ...string) string {
+	if length <= 0 {
+		panic(fmt.Sprintf("zvalidate.PIN: length must be higher than 0: %d", length))
+	}
+	if value == "" {
+		return ""
+	}
+
+	if len(value) != length || !isDigit(value) {
+		v.appendParams(key, "pin", map[string]interface{}{"length": length}, message, length)
+		return ""
+	}
+	if !strong || len(value) == 1 {
+		return value
+	}
+
+	if strings.Count(value, value[:1]) == len(value) {
+		v.appendMessage(key, "pin_repeated", message)
+		return ""
+	}
+
+	asc, desc := true, true
+	for i := 1; i < len(value); i++ {
+		asc = asc && value[i] == value[i-1]+1
+		desc = desc && value[i] == value[i-1]-1
+	}
+	if asc || desc {
+		v.appendMessage(key, "pin_sequence", message)
+		return ""
+	}
+
+	if _, ok := weakPINs[value]; ok {
+		v.appendMessage(key, "pin_common", message)
+		return ""
+	}
+	return value
+}
+
+// Alpha validates that the value contains only letters.
+//
+// Letters in any script are allowed, as are combining marks (such as the vowel
+// signs in "हिन्दी").
+func (v *Validator) Alpha(key, value string, message ...string) {
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+			v.appendMessage(key, "alpha", message)
+			return
+		}
+	}
+}
+
+// Alphanumeric validates that the value contains only letters and digits.
+//
+// Letters and digits in any script are allowed, as are combining marks. Use
+// Contains() with the AlphaNumeric range table to allow only ASCII.
+func (v *Validator) Alphanumeric(key, value string, message ...string) {
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) {
+			v.appendMessage(key, "alphanumeric", message)
+			return
+		}
+	}
+}
+
+// SingleLine validates that the value doesn't contain any newlines.
+//
+// This rejects \n, \r, and the Unicode line breaks U+0085 (NEXT LINE), U+2028
+// (LINE SEPARATOR), and U+2029 (PARAGRAPH SEPARATOR).
+func (v *Validator) SingleLine(key, value string, message ...string) {
+	if strings.IndexFunc(value, isNewline) > -1 {
+		v.appendMessage(key, "single_line", message)
+	}
+}
+
+// SingleLineTrim is like SingleLine(), but allows a single trailing newline
+// (\n, \r\n, or \r), which is removed from the returned value.
+//
+// This is useful for text pasted in to an input field, which often has a
+// trailing newline.
+func (v *Validator) SingleLineTrim(key, value string, message ...string) string {
+	switch {
+	case strings.HasSuffix(value, "\r\n"):
+		value = value[:len(value)-2]
+	case strings.HasSuffix(value, "\n"), strings.HasSuffix(value, "\r"):
+		value = value[:len(value)-1]
+	}
+
+	if strings.IndexFunc(value, isNewline) > -1 {
+		v.appendMessage(key, "single_line", message)
+		return ""
+	}
+	return value
+}
+
+// MaxLines validates that the value has at most max lines, and returns the
+// number of lines.
+//
+// Lines can be separated by \n, \r\n, or \r; a single trailing newline is
+// ignored, so "a\nb\n" is 2 lines.
+func (v *Validator) MaxLines(key, value string, max int, message ...string) int {
+	if value == "" {
+		return 0
+	}
+
+	value = strings.Replace(value, "\r\n", "\n", -1)
+	value = strings.Replace(value, "\r", "\n", -1)
+	value = strings.TrimSuffix(value, "\n")
+	n := strings.Count(value, "\n") + 1
+
+	if n > max {
+		v.appendParams(key, "max_lines", map[string]interface{}{"max": max}, message, max)
+	}
+	return n
+}
+
+func isNewline(r rune) bool {
+	return r == '\n' || r == '\r' || r == 0x85 || r == 0x2028 || r == 0x2029
 }
 
 // Boolean parses as string as a boolean.
@@ -584,7 +1949,7 @@ func (v *Validator) Boolean(key, value string, message ...string) bool {
 	case "0", "n", "no", "f", "false", "off":
 		return false
 	}
-	v.Append(key, getMessage(message, MessageBool))
+	v.appendMessage(key, "bool", message)
 	return false
 }
 
@@ -594,14 +1959,51 @@ func (v *Validator) Date(key, value, layout string, message ...string) time.Time
 		return time.Time{}
 	}
 
-	msg := getMessage(message, "")
 	t, err := time.Parse(layout, value)
 	if err != nil {
-		if msg != "" {
-			v.Append(key, msg)
-		} else {
-			v.Append(key, fmt.Sprintf(MessageDate, layout))
-		}
+		v.appendParams(key, "date", map[string]interface{}{"layout": layout}, message, layout)
+	}
+	return t
+}
+
+// DateOrder parses the start and end dates like Date(), and validates that end
+// isn't before start. The error for this is added to endKey.
+//
+// The order isn't checked if either value is empty or invalid.
+func (v *Validator) DateOrder(startKey, startVal, endKey, endVal, layout string, message ...string) (time.Time, time.Time) {
+	params := map[string]interface{}{"layout": layout}
+
+	start, startErr := time.Parse(layout, startVal)
+	if startVal != "" && startErr != nil {
+		v.appendParams(startKey, "date", params, nil, layout)
+	}
+	end, endErr := time.Parse(layout, endVal)
+	if endVal != "" && endErr != nil {
+		v.appendParams(endKey, "date", params, nil, layout)
+	}
+	if startVal == "" || endVal == "" || startErr != nil || endErr != nil {
+		return start, end
+	}
+
+	if end.Before(start) {
+		v.appendMessage(endKey, "date_order", message)
+	}
+	return start, end
+}
+
+// Timestamp parses a RFC 3339 timestamp, such as "2006-01-02T15:04:05Z" or
+// "2006-01-02T15:04:05.999+07:00".
+//
+// Fractional seconds are optional.
+func (v *Validator) Timestamp(key, value string, message ...string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		v.appendMessage(key, "timestamp", message)
+		return time.Time{}
 	}
 	return t
 }
@@ -622,11 +2024,564 @@ func (v *Validator) Phone(key, value string, message ...string) string {
 		return ""
 	}
 
-	msg := getMessage(message, MessagePhone)
+	msg := getMessage(message, v.message("phone"))
 	if !rePhone.MatchString(value) {
-		v.Append(key, msg)
+		v.AppendCode(key, "phone", msg)
 	}
 
 	return strings.NewReplacer("-", "", "(", "", ")", "", " ", "", ".", "").
 		Replace(value)
 }
+
+// PasswordHash validates that this is a password hash for one of the given
+// algorithms.
+//
+// Supported algorithms are "bcrypt" ("$2a$", "$2b$", and "$2y$"), "argon2id",
+// "argon2i", "argon2d", and "scrypt"; the argon2 and scrypt hashes need to be in
+// the PHC string format (e.g. "$argon2id$v=19$m=65536,t=3,p=4$salt$hash").
+// All supported algorithms are accepted if algorithms is empty.
+//
+// This only checks the syntax: the parameters need to be positive numbers, and
+// the salt and hash need to be correctly encoded with a salt of at least 8
+// bytes and a hash of at least 16 bytes.
+//
+// Returns the detected algorithm.
+func (v *Validator) PasswordHash(key, value string, algorithms []string, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	alg := passwordHashAlgorithm(value)
+	if alg == "" || (len(algorithms) > 0 && !containsString(alg, algorithms)) {
+		v.appendMessage(key, "password_hash", message)
+		return ""
+	}
+	return alg
+}
+
+func passwordHashAlgorithm(h string) string {
+	if len(h) < 2 || h[0] != '$' {
+		return ""
+	}
+
+	parts := strings.Split(h[1:], "$")
+	switch parts[0] {
+	case "2a", "2b", "2y":
+		// $2b$10$ + 22 characters salt + 31 characters hash.
+		if len(parts) != 3 || len(parts[1]) != 2 || len(parts[2]) != 53 {
+			return ""
+		}
+		if cost, err := strconv.Atoi(parts[1]); err != nil || cost < 4 || cost > 31 {
+			return ""
+		}
+		for _, c := range parts[2] {
+			if c != '.' && c != '/' && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') {
+				return ""
+			}
+		}
+		return "bcrypt"
+
+	case "argon2id", "argon2i", "argon2d":
+		// $argon2id$v=19$m=65536,t=3,p=4$salt$hash; the version is optional.
+		p := parts[1:]
+		if len(p) == 4 {
+			if !strings.HasPrefix(p[0], "v=") || !isPositiveInt(p[0][2:]) {
+				return ""
+			}
+			p = p[1:]
+		}
+		if len(p) != 3 || !validPHCParams(p[0], "m", "t", "p") ||
+			!validPHCBase64(p[1], 8) || !validPHCBase64(p[2], 16) {
+			return ""
+		}
+		return parts[0]
+
+	case "scrypt":
+		// $scrypt$ln=15,r=8,p=1$salt$hash
+		if len(parts) != 4 || !validPHCParams(parts[1], "ln", "r", "p") ||
+			!validPHCBase64(parts[2], 8) || !validPHCBase64(parts[3], 16) {
+			return ""
+		}
+		return "scrypt"
+	}
+	return ""
+}
+
+// validPHCParams checks if the parameters are in the form of "a=1,b=2", in the
+// given order.
+func validPHCParams(params string, names ...string) bool {
+	p := strings.Split(params, ",")
+	if len(p) != len(names) {
+		return false
+	}
+	for i := range p {
+		if !strings.HasPrefix(p[i], names[i]+"=") || !isPositiveInt(p[i][len(names[i])+1:]) {
+			return false
+		}
+	}
+	return true
+}
+
+func validPHCBase64(s string, minLen int) bool {
+	b, err := base64.RawStdEncoding.DecodeString(s)
+	return err == nil && len(b) >= minLen
+}
+
+func isPositiveInt(s string) bool {
+	n, err := strconv.ParseUint(s, 10, 32)
+	return err == nil && n > 0 && s[0] != '+'
+}
+
+// TODO: move to zstring
+func containsString(s string, list []string) bool {
+	for _, l := range list {
+		if s == l {
+			return true
+		}
+	}
+	return false
+}
+
+// FilePath validates that this is a valid file path for the current OS.
+//
+// This is intentionally lenient and only rejects paths that can never be valid,
+// such as paths with a NULL byte, or with <>:"|?* or control characters on
+// Windows. It doesn't check if the file exists; use SafePath() for paths that
+// shouldn't escape a directory.
+func (v *Validator) FilePath(key, value string, message ...string) {
+	if value != "" && !validFilePath(value, runtime.GOOS == "windows") {
+		v.appendMessage(key, "file_path", message)
+	}
+}
+
+func validFilePath(p string, windows bool) bool {
+	if strings.IndexByte(p, 0) > -1 {
+		return false
+	}
+	if !windows {
+		return true
+	}
+
+	p = strings.TrimPrefix(p, `\\?\`)
+	for i, c := range p {
+		switch {
+		case c < 0x20, strings.ContainsRune(`<>"|?*`, c):
+			return false
+		// Only allowed as a drive letter, "C:\".
+		case c == ':' && (i != 1 || !unicode.IsLetter(rune(p[0]))):
+			return false
+		}
+	}
+	return true
+}
+
+// SafePath validates that this is a relative path that can't "escape" the
+// directory it's in.
+//
+// Absolute paths, ".." path elements, empty path elements ("a//b"), backslashes,
+// and NULL bytes are rejected. Percent-encoding or other escapes are not
+// decoded; "%2e%2e" is a valid filename.
+//
+// Returns the path cleaned with path.Clean().
+func (v *Validator) SafePath(key, value string, message ...string) string {
+	return v.SafePathLimit(key, value, 0, 0, message...)
+}
+
+// SafePathLimit is like SafePath, but also limits the maximum number of path
+// elements and the maximum character length.
+//
+// A maximum of 0 indicates there is no upper limit.
+func (v *Validator) SafePathLimit(key, value string, maxDepth, maxLen int, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	if !validSafePath(value) {
+		v.appendMessage(key, "safe_path", message)
+		return ""
+	}
+
+	value = path.Clean(value)
+	if maxLen > 0 && utf8.RuneCountInString(value) > maxLen {
+		v.appendParams(key, "len_shorter", map[string]interface{}{"max": maxLen}, message, maxLen)
+		return ""
+	}
+	if maxDepth > 0 && strings.Count(value, "/")+1 > maxDepth {
+		v.appendParams(key, "safe_path_depth", map[string]interface{}{"max": maxDepth}, message, maxDepth)
+		return ""
+	}
+	return value
+}
+
+func validSafePath(p string) bool {
+	if p[0] == '/' || strings.ContainsAny(p, "\\\x00") {
+		return false
+	}
+	for _, e := range strings.Split(p, "/") {
+		if e == "" || e == ".." {
+			return false
+		}
+	}
+
+	// Shouldn't be possible, but better safe than sorry.
+	p = path.Clean(p)
+	return p != "." && p != ".." && !strings.HasPrefix(p, "../") && !strings.HasPrefix(p, "/")
+}
+
+// CountryCode validates that this is an ISO 3166-1 alpha-2 country code, such
+// as "NL" or "US".
+//
+// Returns the code in upper case.
+func (v *Validator) CountryCode(key, value string, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if !isCountryCode(value) {
+		v.appendMessage(key, "country_code", message)
+		return ""
+	}
+	return value
+}
+
+// Subdivision validates that this is an ISO 3166-2 subdivision code, such as
+// "US-CA" or "NL-NH".
+//
+// Returns the code in upper case.
+func (v *Validator) Subdivision(key, value string, message ...string) string {
+	return v.SubdivisionOf(key, value, "", message...)
+}
+
+// SubdivisionOf is like Subdivision, but also validates that the subdivision
+// is in the ISO 3166-1 alpha-2 country code countryCode. Any country is
+// allowed if countryCode is "".
+func (v *Validator) SubdivisionOf(key, value, countryCode string, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if !isSubdivision(value) ||
+		(countryCode != "" && !strings.EqualFold(value[:2], strings.TrimSpace(countryCode))) {
+		v.appendMessage(key, "subdivision", message)
+		return ""
+	}
+	return value
+}
+
+// Currency validates that this is an ISO 4217 currency code, such as "EUR" or
+// "USD".
+//
+// Returns the code in upper case.
+func (v *Validator) Currency(key, value string, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if _, ok := currencies[value]; !ok {
+		v.appendMessage(key, "currency", message)
+		return ""
+	}
+	return value
+}
+
+// Money validates an amount such as "12.50" with an ISO 4217 currency code
+// such as "EUR", and returns the amount in the currency's minor units (1250)
+// and the currency code in upper case.
+//
+// The amount can't have more decimal places than the currency's minor units,
+// so "10.555" is invalid for USD and "10.5" for JPY. Negative amounts are
+// invalid; use MoneySigned() to allow them.
+//
+// If currency is "" the amount can be given as a single string, such as
+// "12.50 EUR".
+//
+// Codes without minor units, such as XXX, XTS, XAU, and XDR, aren't valid
+// currencies for amounts.
+func (v *Validator) Money(key, amount, currency string, message ...string) (int64, string) {
+	return v.money(key, amount, currency, false, message...)
+}
+
+// MoneySigned is like Money, but allows negative amounts.
+func (v *Validator) MoneySigned(key, amount, currency string, message ...string) (int64, string) {
+	return v.money(key, amount, currency, true, message...)
+}
+
+func (v *Validator) money(key, amount, currency string, signed bool, message ...string) (int64, string) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return 0, ""
+	}
+	if currency == "" {
+		if i := strings.LastIndexByte(amount, ' '); i > -1 {
+			amount, currency = strings.TrimSpace(amount[:i]), amount[i+1:]
+		}
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	// Codes such as XXX ("no currency") and XAU (gold) don't have minor units,
+	// and aren't used for money amounts.
+	minor, ok := currencies[currency]
+	if !ok || minor < 0 {
+		v.appendMessage(key, "currency", message)
+		return 0, ""
+	}
+
+	neg := strings.HasPrefix(amount, "-")
+	if neg && !signed {
+		v.appendMessage(key, "money_negative", message)
+		return 0, ""
+	}
+
+	whole, frac := strings.TrimPrefix(amount, "-"), ""
+	i := strings.IndexByte(whole, '.')
+	if i > -1 {
+		whole, frac = whole[:i], whole[i+1:]
+	}
+	if whole == "" || !isDigit(whole) || (i > -1 && (frac == "" || !isDigit(frac))) {
+		v.appendMessage(key, "money", message)
+		return 0, ""
+	}
+	if len(frac) > minor {
+		v.appendParams(key, "money_decimals", map[string]interface{}{"max": minor}, message, minor)
+		return 0, ""
+	}
+
+	n, err := strconv.ParseInt(whole+frac+strings.Repeat("0", minor-len(frac)), 10, 64)
+	if err != nil {
+		v.appendMessage(key, "money", message)
+		return 0, ""
+	}
+	if neg {
+		n = -n
+	}
+	return n, currency
+}
+
+// ABARoutingNumber validates that this is an ABA routing transit number for
+// US banks: 9 digits with a valid checksum. Numbers starting with 5 are
+// reserved for internal use and are not accepted; use
+// ABARoutingNumberInternal() to allow them.
+//
+// Returns the routing number.
+func (v *Validator) ABARoutingNumber(key, value string, message ...string) string {
+	return v.abaRoutingNumber(key, value, false, message...)
+}
+
+// ABARoutingNumberInternal is like ABARoutingNumber, but also allows numbers
+// starting with 5, which are used for internal routing by some banks.
+func (v *Validator) ABARoutingNumberInternal(key, value string, message ...string) string {
+	return v.abaRoutingNumber(key, value, true, message...)
+}
+
+func (v *Validator) abaRoutingNumber(key, value string, internal bool, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) != 9 || !isDigit(value) || (value[0] == '5' && !internal) {
+		v.appendMessage(key, "aba_routing_number", message)
+		return ""
+	}
+
+	var (
+		sum     int
+		weights = [3]int{3, 7, 1}
+	)
+	for i := range value {
+		sum += int(value[i]-'0') * weights[i%3]
+	}
+	if sum%10 != 0 {
+		v.appendMessage(key, "aba_routing_number", message)
+		return ""
+	}
+	return value
+}
+
+// SortCode validates that this is a UK bank sort code: six digits, optionally
+// in pairs separated by a hyphen or space ("12-34-56", "12 34 56", "123456").
+// "000000" is not accepted.
+//
+// Returns the sort code without separators, such as "123456".
+func (v *Validator) SortCode(key, value string, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) == 8 && value[2] == value[5] && (value[2] == '-' || value[2] == ' ') {
+		value = value[:2] + value[3:5] + value[6:]
+	}
+	if len(value) != 6 || !isDigit(value) || value == "000000" {
+		v.appendMessage(key, "sort_code", message)
+		return ""
+	}
+	return value
+}
+
+// Language validates that this is a well-formed BCP 47 language tag, such as
+// "en", "en-US", or "zh-Hant-TW".
+//
+// This only checks the syntax; it doesn't check if the subtags are actually
+// registered. An underscore is accepted as a separator ("en_US").
+//
+// Returns the tag with the canonical separator and casing (e.g. "en_us" is
+// returned as "en-US"). Deprecated subtags aren't replaced with their preferred
+// value.
+func (v *Validator) Language(key, value string, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	msg := getMessage(message, v.message("language"))
+	tag, err := checkBCP47Syntax(strings.TrimSpace(value))
+	if err != nil {
+		v.AppendCode(key, "language", fmt.Sprintf("%s: %s", msg, err))
+		return ""
+	}
+	return tag
+}
+
+// Locale validates that this is a locale as a ISO 639 language code and
+// optional ISO 3166-1 alpha-2 country code, such as "de", "en-US", or "pt_BR".
+//
+// This is a simpler and stricter version of Language(), for when you only want
+// a language and region.
+//
+// Returns the locale as "ll-RR" (e.g. "en_us" is returned as "en-US").
+func (v *Validator) Locale(key, value string, message ...string) string {
+	if value == "" {
+		return ""
+	}
+
+	parts := strings.Split(strings.Replace(strings.TrimSpace(value), "_", "-", -1), "-")
+	if len(parts) > 2 {
+		v.appendMessage(key, "locale", message)
+		return ""
+	}
+
+	lang := strings.ToLower(parts[0])
+	if !isLanguageCode(lang) {
+		v.appendMessage(key, "locale", message)
+		return ""
+	}
+	if len(parts) == 1 {
+		return lang
+	}
+
+	region := strings.ToUpper(parts[1])
+	if !isCountryCode(region) {
+		v.appendMessage(key, "locale", message)
+		return ""
+	}
+	return lang + "-" + region
+}
+
+// Timezone validates that this is a timezone name from the IANA tz database,
+// such as "Europe/Amsterdam" or "UTC".
+//
+// "Local" is not accepted, as it depends on the system's configuration.
+//
+// Returns the location.
+func (v *Validator) Timezone(key, value string, message ...string) *time.Location {
+	if value == "" {
+		return nil
+	}
+
+	msg := getMessage(message, v.message("timezone"))
+	if value == "Local" {
+		v.AppendCode(key, "timezone", msg)
+		return nil
+	}
+
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		v.AppendCode(key, "timezone", msg)
+		return nil
+	}
+	return loc
+}
+
+// Regexp validates that the value is a valid regular expression, returning the
+// compiled regexp.
+//
+// The error from regexp.Compile() is added to the message, e.g. "must be a
+// valid regular expression: missing closing ): `(a`".
+func (v *Validator) Regexp(key, value string, message ...string) *regexp.Regexp {
+	if value == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(value)
+	if err != nil {
+		v.appendMessage(key, "regexp", message, strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+		return nil
+	}
+	return re
+}
+
+// Identifier validates that this is an identifier: it must start with an ASCII
+// letter or underscore, followed by ASCII letters, numbers, or underscores.
+func (v *Validator) Identifier(key, value string, message ...string) {
+	v.IdentifierWith(key, value, 0, nil, message...)
+}
+
+// IdentifierWith is like Identifier, but also allows the characters in extra
+// after the first character, and limits the length to maxLen.
+//
+// A maxLen of 0 indicates there is no upper limit. For example, to allow up to
+// 64 characters and "-" and ".":
+//
+//   v.IdentifierWith("event", event, 64, []rune{'-', '.'})
+func (v *Validator) IdentifierWith(key, value string, maxLen int, extra []rune, message ...string) {
+	if value == "" {
+		return
+	}
+
+	if maxLen > 0 && len(value) > maxLen {
+		v.appendParams(key, "len_shorter", map[string]interface{}{"max": maxLen}, message, maxLen)
+		return
+	}
+
+	for i, c := range value {
+		valid := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(i > 0 && ((c >= '0' && c <= '9') || containsAnyRune(c, extra)))
+		if !valid {
+			v.appendMessage(key, "identifier", message)
+			return
+		}
+	}
+}
+
+// Unique validates that all the values are unique.
+//
+// The error is added for the first duplicate value as "key[n]", where n is the
+// index of the duplicate; e.g. for []string{"a", "b", "a"} the error is added
+// as "key[2]". This is the same format Sub() uses.
+func (v *Validator) Unique(key string, values []string, message ...string) {
+	v.unique(key, values, false, message...)
+}
+
+// UniqueFold is like Unique, but compares the values case-insensitive and with
+// leading and trailing whitespace removed.
+func (v *Validator) UniqueFold(key string, values []string, message ...string) {
+	v.unique(key, values, true, message...)
+}
+
+func (v *Validator) unique(key string, values []string, fold bool, message ...string) {
+	seen := make(map[string]struct{}, len(values))
+	for i, val := range values {
+		k := val
+		if fold {
+			k = strings.ToLower(strings.TrimSpace(val))
+		}
+		if _, ok := seen[k]; ok {
+			v.appendMessage(fmt.Sprintf("%s[%d]", key, i), "unique", message, val)
+			return
+		}
+		seen[k] = struct{}{}
+	}
+}
@@ -2,7 +2,10 @@ package zvalidate
 
 import (
 	"fmt"
+	"math"
+	"net"
 	"net/mail"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
@@ -23,6 +26,12 @@ func TestRequiredInt(t *testing.T) {
 		{int64(1), false},
 		{uint(1), false},
 		{uint64(1), false},
+		{float32(0), true},
+		{float64(0), true},
+		{float32(0.1), false},
+		{float64(-0.1), false},
+		{new(float64), true},
+		{(*float32)(nil), true},
 	}
 
 	for i, tt := range tests {
@@ -37,6 +46,110 @@ func TestRequiredInt(t *testing.T) {
 	}
 }
 
+func TestRequiredTypes(t *testing.T) {
+	type status int
+	type name string
+	type point struct{ X, Y int }
+	var (
+		empty    = ""
+		notEmpty = "x"
+		zero     = 0
+		pEmpty   = &empty
+		now      = time.Now()
+	)
+
+	tests := []struct {
+		in   interface{}
+		want bool
+	}{
+		{nil, true},
+		{(*string)(nil), true},
+		{&empty, true},
+		{&notEmpty, false},
+		{&pEmpty, true},
+		{(**string)(nil), true},
+		{&zero, true},
+		{int8(0), true},
+		{int32(1), false},
+		{uint8(1), false},
+		{status(0), true},
+		{status(1), false},
+		{name(" "), true},
+		{name("x"), false},
+		{&time.Time{}, true},
+		{&now, false},
+		{[]int{}, true},
+		{[]int{0}, false},
+		{[]*string(nil), true},
+		{map[string]string{}, true},
+		{map[string]string{"k": ""}, false},
+		{[2]int{}, true},
+		{[2]int{0, 1}, false},
+		{point{}, true},
+		{point{X: 1}, false},
+		{&point{}, true},
+		{(*point)(nil), true},
+		{&point{Y: 1}, false},
+		{complex(0, 0), true},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			v := New()
+			v.Required("k", tt.in)
+			if got := v.HasErrors(); got != tt.want {
+				t.Errorf("%#v: got %t; want %t", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("panic", func(t *testing.T) {
+		tests := []interface{}{
+			func() {},
+			make(chan int),
+			(func())(nil),
+		}
+		for i, tt := range tests {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Error("no panic")
+					}
+				}()
+				v := New()
+				v.Required("k", tt)
+			})
+		}
+	})
+}
+
+func TestRequiredTrim(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", map[string][]string{"k": {"must be set"}}},
+		{" \t\n", "", map[string][]string{"k": {"must be set"}}},
+		{"x", "x", make(map[string][]string)},
+		{"  Martin Tournoij\n", "Martin Tournoij", make(map[string][]string)},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.RequiredTrim("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidators(t *testing.T) {
 	tests := []struct {
 		val        func(Validator)
@@ -197,6 +310,87 @@ func TestValidators(t *testing.T) {
 			func(v Validator) { v.Required("k1", time.Now()) },
 			make(map[string][]string),
 		},
+		{
+			func(v Validator) {
+				var t *time.Time
+				v.Required("k1", t)
+			},
+			map[string][]string{"k1": {"must be set"}},
+		},
+		{
+			func(v Validator) {
+				t := time.Now()
+				v.Required("k1", &t)
+			},
+			make(map[string][]string),
+		},
+
+		// RequiredFunc
+		{
+			func(v Validator) {
+				v.RequiredFunc("a", func() bool { return false })
+				v.RequiredFunc("b", func() bool { return true })
+				v.RequiredFunc("c", func() bool { return true }, "foo")
+			},
+			map[string][]string{"b": {"must be set"}, "c": {"foo"}},
+		},
+
+		// RequiredIf, RequiredUnless
+		{
+			func(v Validator) {
+				v.RequiredIf("a", "", true)
+				v.RequiredIf("b", "", false)
+				v.RequiredIf("c", "x", true)
+				v.RequiredIf("d", 0, true)
+				v.RequiredIf("e", int64(1), true)
+				v.RequiredIf("f", []string{}, true, "foo")
+				v.RequiredIf("g", struct{}{}, false) // Unsupported type; doesn't panic.
+				v.RequiredUnless("h", "", false)
+				v.RequiredUnless("i", "", true)
+				v.RequiredUnless("j", (*string)(nil), false)
+				v.RequiredUnless("k", time.Time{}, true)
+			},
+			map[string][]string{
+				"a": {"must be set"},
+				"d": {"must be set"},
+				"f": {"foo"},
+				"h": {"must be set"},
+				"j": {"must be set"},
+			},
+		},
+
+		// RequiredKey
+		{
+			func(v Validator) {
+				data := map[string]interface{}{"a": "x", "b": "", "c": nil, "d": 0}
+				v.RequiredKey("a", data)
+				v.RequiredKey("b", data)
+				v.RequiredKey("c", data)
+				v.RequiredKey("d", data)
+				v.RequiredKey("e", data)
+				v.RequiredKey("f", data, "foo")
+				v.RequiredKey("g", nil)
+			},
+			map[string][]string{"e": {"must be set"}, "f": {"foo"}, "g": {"must be set"}},
+		},
+
+		// RequireAny
+		{
+			func(v Validator) { v.RequireAny("k") },
+			map[string][]string{"k": {"at least one must be set"}},
+		},
+		{
+			func(v Validator) { v.RequireAny("k", "", 0, nil) },
+			map[string][]string{"k": {"at least one must be set"}},
+		},
+		{
+			func(v Validator) { v.RequireAny("k", "", "a") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.RequireAny("k", "", mail.Address{Address: "a@example.com"}) },
+			make(map[string][]string),
+		},
 
 		// []int64
 		{
@@ -232,6 +426,49 @@ func TestValidators(t *testing.T) {
 			make(map[string][]string),
 		},
 
+		// RequiredLen
+		{
+			func(v Validator) {
+				v.RequiredLen("a", "w00t", 2, 5)
+				v.RequiredLen("b", "", 2, 5)
+				v.RequiredLen("c", "w", 2, 5)
+				v.RequiredLen("d", "w00t w00t", 2, 5)
+				v.RequiredLen("e", "", 0, 0)
+				v.RequiredLen("f", "", 2, 5, "foo")
+				v.RequiredLen("g", "w", 2, 5, "foo")
+				v.RequiredLen("h", "   ", 2, 5)
+				v.RequiredLen("i", " \t\n", 0, 0)
+			},
+			map[string][]string{
+				"b": {"must be set"},
+				"c": {"must be longer than 2 characters"},
+				"d": {"must be shorter than 5 characters"},
+				"e": {"must be set"},
+				"f": {"foo"},
+				"g": {"foo"},
+				"h": {"must be set"},
+				"i": {"must be set"},
+			},
+		},
+
+		// Words
+		{
+			func(v Validator) {
+				v.Words("a", "", 0, 2)
+				v.Words("b", "one  two\tthree\n", 2, 3)
+				v.Words("c", "  ", 1, 0)
+				v.Words("d", "one two three", 1, 2)
+				v.Words("e", "one two three", 4, 0)
+				v.Words("f", "one two three", 0, 1, "foo")
+			},
+			map[string][]string{
+				"c": {"must be longer than 1 words"},
+				"d": {"must be shorter than 2 words"},
+				"e": {"must be longer than 4 words"},
+				"f": {"foo"},
+			},
+		},
+
 		// Len
 		{
 			func(v Validator) { v.Len("v", "w00t", 2, 5) },
@@ -261,6 +498,76 @@ func TestValidators(t *testing.T) {
 			func(v Validator) { v.Len("v", "ราคาเหนือจอง", 12, 12) },
 			make(map[string][]string),
 		},
+		// LenGraphemes
+		{
+			func(v Validator) {
+				v.LenGraphemes("a", "👩\u200d👩\u200d👧\u200d👦", 1, 1)
+				v.LenGraphemes("b", "e\u0301", 1, 1)
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.LenGraphemes("a", "👍🏽👍🏽👍🏽", 1, 2)
+				v.LenGraphemes("b", "", 1, 2)
+				v.LenGraphemes("c", "abc", 1, 2, "foo")
+			},
+			map[string][]string{
+				"a": {"must be shorter than 2 characters"},
+				"b": {"must be longer than 1 characters"},
+				"c": {"foo"},
+			},
+		},
+		// LenSlice
+		{
+			func(v Validator) { v.LenSlice("v", nil, 0, 0) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.LenSlice("v", []string{"a", "b"}, 1, 2) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.LenSlice("v", map[string]int{"a": 1}, 1, 0) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.LenSlice("v", [3]int{}, 1, 2) },
+			map[string][]string{"v": {"must be shorter than 2 characters"}},
+		},
+		{
+			func(v Validator) { v.LenSlice("v", []int(nil), 1, 2) },
+			map[string][]string{"v": {"must be longer than 1 characters"}},
+		},
+		{
+			func(v Validator) { v.LenSlice("v", []int(nil), 1, 2, "foo") },
+			map[string][]string{"v": {"foo"}},
+		},
+		// SliceLen
+		{
+			func(v Validator) { v.SliceLen("v", 0, 0, 0) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.SliceLen("v", 500, 1, 500) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.SliceLen("v", 1000, 1, 0) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.SliceLen("v", 0, 1, 500) },
+			map[string][]string{"v": {"must have at least 1 items"}},
+		},
+		{
+			func(v Validator) { v.SliceLen("v", 501, 1, 500) },
+			map[string][]string{"v": {"must have at most 500 items"}},
+		},
+		{
+			func(v Validator) { v.SliceLen("v", 501, 1, 500, "foo") },
+			map[string][]string{"v": {"foo"}},
+		},
 		// Exclude
 		{
 			func(v Validator) { v.Exclude("key", "val", []string{}) },
@@ -313,6 +620,100 @@ func TestValidators(t *testing.T) {
 			make(map[string][]string),
 		},
 
+		// ExcludeCase
+		{
+			func(v Validator) { v.ExcludeCase("key", "val", nil) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.ExcludeCase("key", "val", []string{"VAL"}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.ExcludeCase("key", "val", []string{"VAL", "val"}) },
+			map[string][]string{"key": {`cannot be ‘val’`}},
+		},
+		{
+			func(v Validator) { v.ExcludeCase("key", "val", []string{"val"}, "foo") },
+			map[string][]string{"key": {`foo`}},
+		},
+
+		// IncludeCase
+		{
+			func(v Validator) { v.IncludeCase("key", "val", nil) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IncludeCase("key", "Val", []string{"Val", "VAL"}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IncludeCase("key", "val", []string{"Val", "VAL"}) },
+			map[string][]string{"key": {`must be one of ‘Val, VAL’`}},
+		},
+		{
+			func(v Validator) { v.IncludeCase("key", "val", []string{"VAL"}, "foo") },
+			map[string][]string{"key": {`foo`}},
+		},
+
+		// ExcludeInt
+		{
+			func(v Validator) { v.ExcludeInt("key", 0, nil) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.ExcludeInt("key", 1, []int64{2, 3}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.ExcludeInt("key", 0, []int64{0}) },
+			map[string][]string{"key": {`cannot be ‘0’`}},
+		},
+		{
+			func(v Validator) { v.ExcludeInt("key", -3, []int64{2, -3}, "foo") },
+			map[string][]string{"key": {`foo`}},
+		},
+
+		// IncludeInt
+		{
+			func(v Validator) { v.IncludeInt("key", 0, nil) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IncludeInt("key", 5, []int64{1, 2, 5}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IncludeInt("key", 0, []int64{1, 2, 5}) },
+			map[string][]string{"key": {`must be one of ‘1, 2, 5’`}},
+		},
+		{
+			func(v Validator) { v.IncludeInt("key", 3, []int64{1, 2, 5}, "foo") },
+			map[string][]string{"key": {`foo`}},
+		},
+
+		// NotEqual
+		{
+			func(v Validator) { v.NotEqual("key", "", "") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.NotEqual("key", "new", "old") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.NotEqual("key", "Old", "old") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.NotEqual("key", "old", "old") },
+			map[string][]string{"key": {"must be different"}},
+		},
+		{
+			func(v Validator) { v.NotEqual("key", "old", "old", "foo") },
+			map[string][]string{"key": {"foo"}},
+		},
+
 		// Domain
 		{
 			func(v Validator) { v.Domain("v", "") },
@@ -355,6 +756,39 @@ func TestValidators(t *testing.T) {
 			func(v Validator) { v.Domain("v", "ex ample.com") },
 			map[string][]string{"v": {"must be a valid domain: invalid character: ' '"}},
 		},
+		{
+			func(v Validator) {
+				v.Domain("a", strings.Repeat("a", 63)+".com")
+				v.Domain("b", strings.Repeat("a", 63)+"."+strings.Repeat("b", 63)+"."+
+					strings.Repeat("c", 63)+"."+strings.Repeat("d", 61))
+				v.Domain("c", strings.Repeat("a", 63)+"."+strings.Repeat("b", 63)+"."+
+					strings.Repeat("c", 63)+"."+strings.Repeat("d", 61)+".")
+				v.Domain("d", strings.Repeat("ü", 20)+".com")
+				v.Domain("e", "a-b--c.com")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.Domain("a", strings.Repeat("a", 64)+".com")
+				v.Domain("b", strings.Repeat("a", 63)+"."+strings.Repeat("b", 63)+"."+
+					strings.Repeat("c", 63)+"."+strings.Repeat("d", 62))
+				v.Domain("c", strings.Repeat("ü", 60)+".com")
+				v.Domain("d", "-foo.example.com")
+				v.Domain("e", "foo-.example.com")
+				v.Domain("f", "xn---a-.com") // Decodes to "-a".
+				v.Domain("g", "foo..com")
+			},
+			map[string][]string{
+				"a": {"must be a valid domain: label is longer than 63 bytes"},
+				"b": {"must be a valid domain: domain is longer than 253 bytes"},
+				"c": {"must be a valid domain: label is longer than 63 bytes"},
+				"d": {"must be a valid domain: label can't start or end with a hyphen"},
+				"e": {"must be a valid domain: label can't start or end with a hyphen"},
+				"f": {"must be a valid domain: label can't start or end with a hyphen"},
+				"g": {"must be a valid domain: empty label"},
+			},
+		},
 
 		// Hostname
 		{
@@ -462,6 +896,161 @@ func TestValidators(t *testing.T) {
 			func(v Validator) { v.URL("v", "example.com:-)") },
 			map[string][]string{"v": {"must be a valid url"}},
 		},
+		{
+			func(v Validator) {
+				v.URLPath("a", "")
+				v.URLPath("b", "https://example.com/hook")
+				v.URLPath("c", "example.com/a/b?q=1")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.URLPath("a", "https://example.com")
+				v.URLPath("b", "https://example.com/")
+				v.URLPath("c", "https://example.com/?hook=1")
+				v.URLPath("d", "https://x", "foo")
+				v.URLPath("e", "https://example.com", "foo")
+			},
+			map[string][]string{
+				"a": {"must be a valid url with a path"},
+				"b": {"must be a valid url with a path"},
+				"c": {"must be a valid url with a path"},
+				"d": {"foo"},
+				"e": {"foo"},
+			},
+		},
+		{
+			func(v Validator) {
+				v.URL("a", "http://192.168.1.10:8080/hook")
+				v.URL("b", "http://[::1]/x")
+				v.URL("c", "https://[2001:db8::1]:8443/x")
+				v.URL("d", "192.168.1.10/hook")
+				v.URL("e", "192.168.1.10:8080/hook")
+				v.URL("f", "[::1]:8080")
+				v.URL("g", "example.com:8080/x")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.URLNoIP("a", "http://192.168.1.10:8080/hook")
+				v.URLNoIP("b", "http://[::1]/x")
+				v.URLNoIP("c", "192.168.1.10", "foo")
+				v.URLNoIP("d", "https://example.com")
+			},
+			map[string][]string{
+				"a": {"cannot be an IP address"},
+				"b": {"cannot be an IP address"},
+				"c": {"foo"},
+			},
+		},
+		{
+			func(v Validator) {
+				v.URL("a", "http://1234")
+				v.URL("b", "1234")
+				v.URL("c", "http://42/x")
+				v.URLLocal("d", "http://1234")
+				v.URLLocal("e", "1234")
+				v.URLLocal("f", "http://42/x")
+				v.URLLocal("g", "http://1234./x")
+				v.URLLocal("h", "http://1234-a")
+				v.URLNoIP("i", "http://1.2")
+				v.URLNoIP("j", "http://0x7f.0.0.1")
+			},
+			map[string][]string{
+				"a": {"must be a valid url"},
+				"b": {"must be a valid url"},
+				"c": {"must be a valid url"},
+				"d": {"must be a valid url"},
+				"e": {"must be a valid url"},
+				"f": {"must be a valid url"},
+				"g": {"must be a valid url"},
+			},
+		},
+		{
+			func(v Validator) {
+				v.URLPublic("a", "http://169.254.169.254/latest/meta-data")
+				v.URLPublic("b", "http://[::1]:8080/x")
+				v.URLPublic("c", "10.0.0.1/hook")
+				v.URLPublic("d", "http://127.0.0.1", "foo")
+				v.URLPublic("e", "http://localhost/x")
+				v.URLPublic("f", "https://example.com/hook")
+				v.URLPublic("g", "https://8.8.8.8/hook")
+				v.URLPublic("h", "")
+			},
+			map[string][]string{
+				"a": {"must be a public IP address"},
+				"b": {"must be a public IP address"},
+				"c": {"must be a public IP address"},
+				"d": {"foo"},
+				"e": {"must be a valid url"},
+			},
+		},
+		{
+			func(v Validator) {
+				v.URLPublic("a", "http://127.1/")
+				v.URLPublic("b", "http://0177.0.0.1/")
+				v.URLPublic("c", "http://0x7f.0.0.1/")
+				v.URLPublic("d", "http://10.1/x")
+				v.URLPublic("e", "http://0x7f000001/")
+				v.URLPublic("f", "http://2130706433/")
+				v.URLPublic("g", "http://127.0.0.1./")
+				v.URLPublic("h", "http://0xa9.254.169.254/")
+				v.URLPublic("i", "http://1.2.3.4.5/")
+				v.URLPublic("j", "http://127.0.0.0x1/")
+				v.URLPublic("k", "http://8.8.2056/")
+				v.URLPublic("l", "http://0x08.8.8.8/")
+				v.URLPublic("m", "http://example.com/")
+			},
+			map[string][]string{
+				"a": {"must be a public IP address"},
+				"b": {"must be a public IP address"},
+				"c": {"must be a public IP address"},
+				"d": {"must be a public IP address"},
+				"e": {"must be a public IP address"},
+				"f": {"must be a public IP address"},
+				"g": {"must be a public IP address"},
+				"h": {"must be a public IP address"},
+				"i": {"must be a public IP address"},
+				"j": {"must be a public IP address"},
+			},
+		},
+		{
+			func(v Validator) {
+				v.URLRequireScheme("a", "", []string{"https"})
+				v.URLRequireScheme("b", "https://example.com/hook", []string{"https"})
+				v.URLRequireScheme("c", "HTTPS://example.com/hook", []string{"https"})
+				v.URLRequireScheme("d", "ftp://example.com", nil)
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.URLRequireScheme("a", "example.com/hook", []string{"https"})
+				v.URLRequireScheme("b", "http://example.com/hook", []string{"https"})
+				v.URLRequireScheme("c", "example.com", []string{"https", "http"})
+				v.URLRequireScheme("d", "example.com", nil)
+				v.URLRequireScheme("e", "example.com", nil, "foo")
+				v.URLRequireScheme("f", "https://x", []string{"https"})
+				v.URLRequireScheme("g", "example.com/x?r=http://a", nil)
+				v.URLRequireScheme("h", "example.com/x?r=https://a", []string{"https"})
+				v.URLRequireScheme("i", "example.com:8080/x?r=https://a", nil)
+				v.URLRequireScheme("j", "https:/example.com", []string{"https"})
+			},
+			map[string][]string{
+				"a": {"must include https://"},
+				"b": {"must include https://"},
+				"c": {"must include https:// or http://"},
+				"d": {"must include a scheme"},
+				"e": {"foo"},
+				"f": {"must be a valid url"},
+				"g": {"must include a scheme"},
+				"h": {"must include https://"},
+				"i": {"must include a scheme"},
+				"j": {"must include https://"},
+			},
+		},
 		// Format changed in Go 1.14
 		//{
 		//	func(v Validator) { v.URL("v", "ex ample.com") },
@@ -518,9 +1107,58 @@ func TestValidators(t *testing.T) {
 			map[string][]string{"k": {"not valid"}},
 		},
 
-		// Email
+		// DateOrder
 		{
-			func(v Validator) { v.Email("v", "") },
+			func(v Validator) {
+				v.DateOrder("s1", "", "e1", "2020-01-01", "2006-01-02")
+				v.DateOrder("s2", "2020-01-01", "e2", "", "2006-01-02")
+				v.DateOrder("s3", "2020-01-01", "e3", "2020-01-01", "2006-01-02")
+				v.DateOrder("s4", "2020-01-01", "e4", "2020-01-02", "2006-01-02")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.DateOrder("s1", "2020-01-02", "e1", "2020-01-01", "2006-01-02")
+				v.DateOrder("s2", "2020-01-02", "e2", "2020-01-01", "2006-01-02", "foo")
+				v.DateOrder("s3", "2020-01-02", "e3", "xxx", "2006-01-02")
+			},
+			map[string][]string{
+				"e1": {"cannot be before the start date"},
+				"e2": {"foo"},
+				"e3": {"must be a date as ‘2006-01-02’"},
+			},
+		},
+		{
+			func(v Validator) {
+				p := v.Prefixed("p")
+				p.DateOrder("s1", "2020-01-02", "e1", "2020-01-01", "2006-01-02")
+				p.DateOrder("s2", "xxx", "e2", "2020-01-01", "2006-01-02")
+				v.If(true).DateOrder("s3", "2020-01-02", "e3", "2020-01-01", "2006-01-02")
+			},
+			map[string][]string{
+				"p.e1": {"cannot be before the start date"},
+				"p.s2": {"must be a date as ‘2006-01-02’"},
+				"e3":   {"cannot be before the start date"},
+			},
+		},
+		{
+			func(v Validator) {
+				v.OnlyFirst()
+				v.Required("s1", "")
+				v.DateOrder("s1", "xxx", "e1", "2020-01-01", "2006-01-02")
+				v.Required("e2", "")
+				v.DateOrder("s2", "2020-01-02", "e2", "xxx", "2006-01-02")
+			},
+			map[string][]string{
+				"s1": {"must be set"},
+				"e2": {"must be set"},
+			},
+		},
+
+		// Email
+		{
+			func(v Validator) { v.Email("v", "") },
 			make(map[string][]string),
 		},
 		{
@@ -579,6 +1217,44 @@ func TestValidators(t *testing.T) {
 			map[string][]string{"v": {"must be a valid IPv4 address"}},
 		},
 
+		// IPv6
+		{
+			func(v Validator) { v.IPv6("v", "") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IPv6("v", "::1") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IPv6("v", "2001:db8::68") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IPv6("v", "fe80::1%eth0") },
+			map[string][]string{"v": {"must be a valid IPv6 address"}},
+		},
+		{
+			func(v Validator) { v.IPv6("v", "127.0.0.1") },
+			map[string][]string{"v": {"must be a valid IPv6 address"}},
+		},
+		{
+			func(v Validator) { v.IPv6("v", "::ffff:127.0.0.1") },
+			map[string][]string{"v": {"must be a valid IPv6 address"}},
+		},
+		{
+			func(v Validator) { v.IPv6("v", "2001:db8::/32") },
+			map[string][]string{"v": {"must be a valid IPv6 address"}},
+		},
+		{
+			func(v Validator) { v.IPv6("v", "asdf") },
+			map[string][]string{"v": {"must be a valid IPv6 address"}},
+		},
+		{
+			func(v Validator) { v.IPv6("v", "127.0.0.1", "foo") },
+			map[string][]string{"v": {"foo"}},
+		},
+
 		// IP
 		{
 			func(v Validator) { v.IP("v", "") },
@@ -618,280 +1294,2053 @@ func TestValidators(t *testing.T) {
 			map[string][]string{"v": {"must be a valid IPv4 or IPv6 address"}},
 		},
 
-		// Phone
+		// PublicIP
 		{
-			func(v Validator) { v.Phone("v", "") },
+			func(v Validator) { v.PublicIP("v", "") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) { v.Phone("v", "12345123") },
+			func(v Validator) { v.PublicIP("v", "8.8.8.8") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) { v.Phone("v", "(+31)-12345123") },
+			func(v Validator) { v.PublicIP("v", "2001:4860:4860::8888") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) { v.Phone("v", "[+31]-12345123") },
-			map[string][]string{"v": {"must be a valid phone number"}},
+			func(v Validator) { v.PublicIP("v", "127.0.0.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
-
-		// Range
 		{
-			func(v Validator) { v.Range("v", 4, 2, 5) },
-			make(map[string][]string),
+			func(v Validator) { v.PublicIP("v", "::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.Range("v", 4, 4, 0) },
-			make(map[string][]string),
+			func(v Validator) { v.PublicIP("v", "10.1.2.3") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.Range("v", 4, 0, 4) },
-			make(map[string][]string),
+			func(v Validator) { v.PublicIP("v", "172.20.0.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.Range("v", 4, 1, 2) },
-			map[string][]string{"v": {"must be 2 or lower"}},
+			func(v Validator) { v.PublicIP("v", "192.168.1.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.Range("v", 4, 1, 2, "foo") },
-			map[string][]string{"v": {"foo"}},
+			func(v Validator) { v.PublicIP("v", "169.254.169.254") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.Range("v", 4, 16, 32) },
-			map[string][]string{"v": {"must be 16 or higher"}},
+			func(v Validator) { v.PublicIP("v", "fe80::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
-
-		// UTF8
 		{
-			func(v Validator) { v.UTF8("v", "") },
+			func(v Validator) { v.PublicIP("v", "fd00::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "0.0.0.0") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "::ffff:127.0.0.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "asdf") },
+			map[string][]string{"v": {"must be a valid IPv4 or IPv6 address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "0.1.2.3") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "100.64.0.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "100.127.255.254") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "100.128.0.1") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) { v.UTF8("v", "h€y") },
+			func(v Validator) { v.PublicIP("v", "255.255.255.255") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "224.0.0.251") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "239.1.2.3") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "ff02::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "ff0e::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "64:ff9b::a00:1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "64:ff9b::7f00:1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "64:ff9b::808:808") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) { v.UTF8("v", "h€y\x00a") },
-			map[string][]string{"v": {"must be UTF-8"}},
+			func(v Validator) { v.PublicIP("v", "2002:7f00:1::") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.UTF8("v", "h\xc0\xaeya") },
-			map[string][]string{"v": {"must be UTF-8"}},
+			func(v Validator) { v.PublicIP("v", "2002:a00:1::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
-
-		// Contains
 		{
-			func(v Validator) { v.Contains("v", "€", []*unicode.RangeTable{ASCII}, nil) },
-			map[string][]string{"v": {"cannot contain the characters '€'"}},
+			func(v Validator) { v.PublicIP("v", "::127.0.0.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.Contains("v", "abc€def£", []*unicode.RangeTable{ASCII}, nil) },
-			map[string][]string{"v": {"cannot contain the characters '€', '£'"}},
+			func(v Validator) { v.PublicIP("v", "::10.0.0.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) {
-				v.Contains("v", "abc€def£", []*unicode.RangeTable{ASCII}, nil, "no %s allowed; must be ASCII")
-			},
-			map[string][]string{"v": {"no '€', '£' allowed; must be ASCII"}},
+			func(v Validator) { v.PublicIP("v", "::0.0.0.2") },
+			map[string][]string{"v": {"must be a public IP address"}},
 		},
 		{
-			func(v Validator) { v.Contains("v", "€", []*unicode.RangeTable{ASCII}, []rune{'€'}) },
+			func(v Validator) { v.PublicIP("v", "192.0.2.1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "198.51.100.7") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "203.0.113.255") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "2001:db8::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "fec0::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "feff::1") },
+			map[string][]string{"v": {"must be a public IP address"}},
+		},
+		{
+			func(v Validator) { v.PublicIP("v", "2002:808:808::1") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) { v.Contains("v", "€", nil, []rune{'€'}) },
+			func(v Validator) { v.PublicIP("v", "::8.8.8.8") },
 			make(map[string][]string),
 		},
-	}
-
-	for i, tt := range tests {
-		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
-			v := New()
-			tt.val(v)
-
-			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
-				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
-			}
-		})
-	}
-}
-
-func TestInteger(t *testing.T) {
-	tests := []struct {
-		val        func(Validator) int64
-		want       int64
-		wantErrors map[string][]string
-	}{
 		{
-			func(v Validator) int64 { return v.Integer("k", "") },
-			0,
+			func(v Validator) { v.PublicIP("v", "192.0.3.1") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) int64 { return v.Integer("k", "6") },
-			6,
+			func(v Validator) { v.PublicIP("v", "2001:db9::1") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) int64 { return v.Integer("k", " 6 ") },
-			6,
+			func(v Validator) { v.PublicIP("v", "127.0.0.1", "foo") },
+			map[string][]string{"v": {"foo"}},
+		},
+
+		// IPIn
+		{
+			func(v Validator) { v.IPIn("v", nil, []*net.IPNet{cidr("10.0.0.0/8")}) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) int64 { return v.Integer("k", "0") },
-			0,
+			func(v Validator) { v.IPIn("v", net.ParseIP("10.1.2.3"), []*net.IPNet{cidr("10.0.0.0/8")}) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) int64 { return v.Integer("k", "-1") },
-			-1,
+			func(v Validator) {
+				v.IPIn("v", net.ParseIP("fd00::1"), []*net.IPNet{cidr("10.0.0.0/8"), cidr("fd00::/8")})
+			},
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) int64 { return v.Integer("k", "1.2") },
-			0,
-			map[string][]string{"k": {"must be a whole number"}},
+			func(v Validator) {
+				v.IPIn("v", net.ParseIP("192.168.1.1"), []*net.IPNet{cidr("10.0.0.0/8"), cidr("fd00::/8")})
+			},
+			map[string][]string{"v": {"must be in one of the networks ‘10.0.0.0/8, fd00::/8’"}},
 		},
 		{
-			func(v Validator) int64 { return v.Integer("k", "asd") },
-			0,
-			map[string][]string{"k": {"must be a whole number"}},
+			func(v Validator) { v.IPIn("v", net.ParseIP("192.168.1.1"), nil, "foo") },
+			map[string][]string{"v": {"foo"}},
 		},
-	}
-
-	for i, tt := range tests {
-		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
-			v := New()
-			i := tt.val(v)
-
-			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
-				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
-			}
-
-			if i != tt.want {
-				t.Errorf("\nout:  %#v\nwant: %#v\n", i, tt.want)
-			}
-		})
-	}
-}
 
-func TestBoolean(t *testing.T) {
-	tests := []struct {
-		val        func(Validator) bool
-		want       bool
-		wantErrors map[string][]string
-	}{
+		// Phone
 		{
-			func(v Validator) bool { return v.Boolean("k", "on") },
-			true,
+			func(v Validator) { v.Phone("v", "") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "") },
-			false,
+			func(v Validator) { v.Phone("v", "12345123") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "0") },
-			false,
+			func(v Validator) { v.Phone("v", "(+31)-12345123") },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "n") },
-			false,
-			make(map[string][]string),
+			func(v Validator) { v.Phone("v", "[+31]-12345123") },
+			map[string][]string{"v": {"must be a valid phone number"}},
 		},
+
+		// Range
 		{
-			func(v Validator) bool { return v.Boolean("k", "no") },
-			false,
+			func(v Validator) { v.Range("v", 4, 2, 5) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "f") },
-			false,
+			func(v Validator) { v.Range("v", 4, 4, 0) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "false") },
-			false,
+			func(v Validator) { v.Range("v", 4, 0, 4) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "FALSE") },
-			false,
-			make(map[string][]string),
+			func(v Validator) { v.Range("v", 4, 1, 2) },
+			map[string][]string{"v": {"must be 2 or lower"}},
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "1") },
-			true,
+			func(v Validator) { v.Range("v", 4, 1, 2, "foo") },
+			map[string][]string{"v": {"foo"}},
+		},
+		{
+			func(v Validator) { v.Range("v", 4, 16, 32) },
+			map[string][]string{"v": {"must be 16 or higher"}},
+		},
+
+		// MultipleOf
+		{
+			func(v Validator) { v.MultipleOf("v", 0, 15) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "y") },
-			true,
+			func(v Validator) { v.MultipleOf("v", 45, 15) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "yes") },
-			true,
+			func(v Validator) { v.MultipleOf("v", -30, 15) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "t") },
-			true,
+			func(v Validator) { v.MultipleOf("v", 40, 15) },
+			map[string][]string{"v": {"must be a multiple of 15"}},
+		},
+		{
+			func(v Validator) { v.MultipleOf("v", 40, 15, "foo") },
+			map[string][]string{"v": {"foo"}},
+		},
+		{
+			func(v Validator) { v.MultipleOfFloat("v", 0, 0.25, 1e-9) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "true") },
-			true,
+			func(v Validator) { v.MultipleOfFloat("v", 1.75, 0.25, 1e-9) },
 			make(map[string][]string),
 		},
 		{
-			func(v Validator) bool { return v.Boolean("k", "TRUE") },
-			true,
+			func(v Validator) { v.MultipleOfFloat("v", 0.3, 0.1, 1e-9) },
 			make(map[string][]string),
 		},
-	}
+		{
+			func(v Validator) { v.MultipleOfFloat("v", 1.8, 0.25, 1e-9) },
+			map[string][]string{"v": {"must be a multiple of 0.25"}},
+		},
+
+		// Positive, Negative, NotZero
+		{
+			func(v Validator) {
+				v.Positive("a", 1)
+				v.PositiveFloat("b", 0.1)
+				v.Negative("c", -1)
+				v.NegativeFloat("d", -0.1)
+				v.NotZero("e", -1)
+				v.NotZeroFloat("f", 0.1)
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.Positive("a", 0)
+				v.PositiveFloat("b", -0.1)
+				v.Negative("c", 0)
+				v.NegativeFloat("d", 0.1)
+				v.NotZero("e", 0)
+				v.NotZeroFloat("f", 0, "foo")
+			},
+			map[string][]string{
+				"a": {"must be a positive number"},
+				"b": {"must be a positive number"},
+				"c": {"must be a negative number"},
+				"d": {"must be a negative number"},
+				"e": {"must not be zero"},
+				"f": {"foo"},
+			},
+		},
+		{
+			func(v Validator) { v.PositiveFloat("v", math.NaN()) },
+			map[string][]string{"v": {"must be a positive number"}},
+		},
+
+		// Identifier
+		{
+			func(v Validator) { v.Identifier("v", "") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.Identifier("v", "HOME") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.Identifier("v", "_custom_field2") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.Identifier("v", "2fa") },
+			map[string][]string{"v": {"must be a valid identifier"}},
+		},
+		{
+			func(v Validator) { v.Identifier("v", "custom-field") },
+			map[string][]string{"v": {"must be a valid identifier"}},
+		},
+		{
+			func(v Validator) { v.Identifier("v", "naïve") },
+			map[string][]string{"v": {"must be a valid identifier"}},
+		},
+		{
+			func(v Validator) { v.Identifier("v", "x y", "foo") },
+			map[string][]string{"v": {"foo"}},
+		},
+		{
+			func(v Validator) { v.IdentifierWith("v", "invoice.paid-late", 0, []rune{'-', '.'}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IdentifierWith("v", ".paid", 0, []rune{'-', '.'}) },
+			map[string][]string{"v": {"must be a valid identifier"}},
+		},
+		{
+			func(v Validator) { v.IdentifierWith("v", "abcd", 4, nil) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.IdentifierWith("v", "abcde", 4, nil) },
+			map[string][]string{"v": {"must be shorter than 4 characters"}},
+		},
+
+		// Unique
+		{
+			func(v Validator) { v.Unique("v", nil) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.Unique("v", []string{"a", "A", " a"}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.Unique("v", []string{"a", "b", "c", "b", "a"}) },
+			map[string][]string{"v[3]": {"duplicate value ‘b’"}},
+		},
+		{
+			func(v Validator) { v.Unique("v", []string{"a", "a"}, "foo") },
+			map[string][]string{"v[1]": {"foo"}},
+		},
+		{
+			func(v Validator) { v.UniqueFold("v", []string{"a", "b", "c"}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.UniqueFold("v", []string{"a", "b", " A "}) },
+			map[string][]string{"v[2]": {"duplicate value ‘ A ’"}},
+		},
+
+		// UTF8
+		{
+			func(v Validator) { v.UTF8("v", "") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.UTF8("v", "h€y") },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.UTF8("v", "h€y\x00a") },
+			map[string][]string{"v": {"must be UTF-8"}},
+		},
+		{
+			func(v Validator) { v.UTF8("v", "h\xc0\xaeya") },
+			map[string][]string{"v": {"must be UTF-8"}},
+		},
+
+		// Alpha, Alphanumeric
+		{
+			func(v Validator) {
+				v.Alpha("a", "")
+				v.Alpha("b", "Martin")
+				v.Alpha("c", "ราคาเหนือจอง")
+				v.Alpha("d", "हिन्दी")
+				v.Alphanumeric("e", "")
+				v.Alphanumeric("f", "martin42")
+				v.Alphanumeric("g", "ไทย๑๒๓")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.Alpha("a", "martin42")
+				v.Alpha("b", "Martin Tournoij")
+				v.Alpha("c", "martin_", "foo")
+				v.Alphanumeric("d", "martin_42")
+				v.Alphanumeric("e", "martin 42")
+				v.Alphanumeric("f", "½")
+			},
+			map[string][]string{
+				"a": {"must contain only letters"},
+				"b": {"must contain only letters"},
+				"c": {"foo"},
+				"d": {"must contain only letters and digits"},
+				"e": {"must contain only letters and digits"},
+				"f": {"must contain only letters and digits"},
+			},
+		},
+
+		// SingleLine
+		{
+			func(v Validator) {
+				v.SingleLine("a", "")
+				v.SingleLine("b", "Hello, world\t!")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.SingleLine("a", "Hello\nworld")
+				v.SingleLine("b", "Hello\r")
+				v.SingleLine("c", "Hello\u2028world")
+				v.SingleLine("d", "Hello\u2029world")
+				v.SingleLine("e", "Hello\u0085world", "foo")
+			},
+			map[string][]string{
+				"a": {"must be a single line"},
+				"b": {"must be a single line"},
+				"c": {"must be a single line"},
+				"d": {"must be a single line"},
+				"e": {"foo"},
+			},
+		},
+
+		// NoEmoji
+		{
+			func(v Validator) {
+				v.NoEmoji("a", "")
+				v.NoEmoji("b", "Martin Tournoij")
+				v.NoEmoji("c", "ราคาเหนือจอง © ☺ ™ #1")
+				v.NoEmoji("d", "क्‍ष") // ZWJ in Devanagari.
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.NoEmoji("a", "hello 😀")
+				v.NoEmoji("b", "❤️")          // Text default + U+FE0F.
+				v.NoEmoji("c", "🇳🇱")          // Regional indicators.
+				v.NoEmoji("d", "a\U0001f3fd") // Skin tone.
+				v.NoEmoji("e", "1️⃣")         // Keycap.
+				v.NoEmoji("f", "👩‍💻")         // ZWJ sequence.
+				v.NoEmoji("g", "🫠")           // Unicode 14.
+				v.NoEmoji("h", "🪿", "foo")    // Unicode 15.
+			},
+			map[string][]string{
+				"a": {"must not contain emoji"},
+				"b": {"must not contain emoji"},
+				"c": {"must not contain emoji"},
+				"d": {"must not contain emoji"},
+				"e": {"must not contain emoji"},
+				"f": {"must not contain emoji"},
+				"g": {"must not contain emoji"},
+				"h": {"foo"},
+			},
+		},
+
+		// Printable
+		{
+			func(v Validator) {
+				v.Printable("a", "")
+				v.Printable("b", "Hello,\tworld\r\n")
+				v.Printable("c", "می\u200cخواهم")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.Printable("a", "Hello\x00")
+				v.Printable("b", "\x1b[31mred")
+				v.Printable("c", "zero\u200bwidth")
+				v.Printable("d", "\ufeffbom")
+				v.Printable("e", "\u0080", "foo")
+			},
+			map[string][]string{
+				"a": {"cannot contain control or invisible characters"},
+				"b": {"cannot contain control or invisible characters"},
+				"c": {"cannot contain control or invisible characters"},
+				"d": {"cannot contain control or invisible characters"},
+				"e": {"foo"},
+			},
+		},
+
+		// NoSpace
+		{
+			func(v Validator) {
+				v.NoSpace("a", "")
+				v.NoSpace("b", "abc")
+				v.NoSpace("c", "a b")
+			},
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) {
+				v.NoSpace("a", "abc ")
+				v.NoSpace("b", " abc")
+				v.NoSpace("c", "abc\n")
+				v.NoSpace("d", "\u00a0abc")
+				v.NoSpace("e", " ")
+				v.NoSpace("f", "abc ", "foo")
+			},
+			map[string][]string{
+				"a": {"cannot start or end with whitespace"},
+				"b": {"cannot start or end with whitespace"},
+				"c": {"cannot start or end with whitespace"},
+				"d": {"cannot start or end with whitespace"},
+				"e": {"cannot start or end with whitespace"},
+				"f": {"foo"},
+			},
+		},
+
+		// Contains
+		{
+			func(v Validator) { v.Contains("v", "€", []*unicode.RangeTable{ASCII}, nil) },
+			map[string][]string{"v": {"cannot contain the characters '€'"}},
+		},
+		{
+			func(v Validator) { v.Contains("v", "abc€def£", []*unicode.RangeTable{ASCII}, nil) },
+			map[string][]string{"v": {"cannot contain the characters '€', '£'"}},
+		},
+		{
+			func(v Validator) {
+				v.Contains("v", "abc€def£", []*unicode.RangeTable{ASCII}, nil, "no %s allowed; must be ASCII")
+			},
+			map[string][]string{"v": {"no '€', '£' allowed; must be ASCII"}},
+		},
+		{
+			func(v Validator) { v.Contains("v", "€", []*unicode.RangeTable{ASCII}, []rune{'€'}) },
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) { v.Contains("v", "€", nil, []rune{'€'}) },
+			make(map[string][]string),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			tt.val(v)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func cidr(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func TestIPv6Return(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", "[]"},
+		{"x", "[]"},
+		{"127.0.0.1", "[]"},
+		{"::1", "::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.IPv6("k", tt.in)
+			if out == nil {
+				t.Fatal("nil")
+			}
+			have := fmt.Sprintf("%v", []byte(out))
+			if len(out) > 0 {
+				have = out.String()
+			}
+			if have != tt.want {
+				t.Errorf("\nout:  %s\nwant: %s\n", have, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIPv4Loose(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"127.0.0.1", "127.0.0.1"},
+		{"127.1", "127.0.0.1"},
+		{"127.0.1", "127.0.0.1"},
+		{"10.1", "10.0.0.1"},
+		{"0177.0.0.1", "127.0.0.1"},
+		{"0x7f.0.0.1", "127.0.0.1"},
+		{"0X7F.0.0.1", "127.0.0.1"},
+		{"0x7f000001", "127.0.0.1"},
+		{"2130706433", "127.0.0.1"},
+		{"1.2.3.4.", "1.2.3.4"},
+		{"1.65535", "1.0.255.255"},
+		{"1.16777215", "1.255.255.255"},
+
+		{"", "<nil>"},
+		{"1.2.3.4.5", "<nil>"},
+		{"256.1.1.1", "<nil>"},
+		{"1.2.3.256", "<nil>"},
+		{"1.2.65536", "<nil>"},
+		{"4294967296", "<nil>"},
+		{"08.1.1.1", "<nil>"},
+		{"0x.1.1.1", "<nil>"},
+		{"1..1", "<nil>"},
+		{"1_0.1", "<nil>"},
+		{"+1.1", "<nil>"},
+		{"example.com", "<nil>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if out := parseIPv4Loose(tt.in).String(); out != tt.want {
+				t.Errorf("\nout:  %s\nwant: %s\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestInteger(t *testing.T) {
+	tests := []struct {
+		val        func(Validator) int64
+		want       int64
+		wantErrors map[string][]string
+	}{
+		{
+			func(v Validator) int64 { return v.Integer("k", "") },
+			0,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) int64 { return v.Integer("k", "6") },
+			6,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) int64 { return v.Integer("k", " 6 ") },
+			6,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) int64 { return v.Integer("k", "0") },
+			0,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) int64 { return v.Integer("k", "-1") },
+			-1,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) int64 { return v.Integer("k", "1.2") },
+			0,
+			map[string][]string{"k": {"must be a whole number"}},
+		},
+		{
+			func(v Validator) int64 { return v.Integer("k", "asd") },
+			0,
+			map[string][]string{"k": {"must be a whole number"}},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			i := tt.val(v)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+
+			if i != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", i, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositiveInteger(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       int64
+		wantErrors map[string][]string
+	}{
+		{"", 0, make(map[string][]string)},
+		{"1", 1, make(map[string][]string)},
+		{" 42 ", 42, make(map[string][]string)},
+
+		{"0", 0, map[string][]string{"k": {"must be a positive number"}}},
+		{"-1", 0, map[string][]string{"k": {"must be a positive number"}}},
+		{"1.5", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"x", 0, map[string][]string{"k": {"must be a whole number"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.PositiveInteger("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntegerLoose(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       int64
+		wantErrors map[string][]string
+	}{
+		{"", 0, make(map[string][]string)},
+		{"6", 6, make(map[string][]string)},
+		{" -6 ", -6, make(map[string][]string)},
+		{"1,000", 1000, make(map[string][]string)},
+		{"1 000", 1000, make(map[string][]string)},
+		{"1\u00a0000", 1000, make(map[string][]string)},
+		{"1'000'000", 1000000, make(map[string][]string)},
+		{"-12,345,678", -12345678, make(map[string][]string)},
+		{"100000", 100000, make(map[string][]string)},
+
+		{"1.000", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1.2", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1,00", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1,0000", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1000,000", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{",000", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1,000 000", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1,,000", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1,000,", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"1,abc", 0, map[string][]string{"k": {"must be a whole number"}}},
+		{"asd", 0, map[string][]string{"k": {"must be a whole number"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.IntegerLoose("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestOTP(t *testing.T) {
+	tests := []struct {
+		in         string
+		digits     int
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", 6, "", make(map[string][]string)},
+		{"123456", 6, "123456", make(map[string][]string)},
+		{" 123456 ", 6, "123456", make(map[string][]string)},
+		{"123 456", 6, "123456", make(map[string][]string)},
+		{"123-456", 6, "123456", make(map[string][]string)},
+		{"1234-5678", 8, "12345678", make(map[string][]string)},
+		{"012345", 6, "012345", make(map[string][]string)},
+
+		{"12345", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"1234567", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"123456", 8, "", map[string][]string{"k": {"must be a 8-digit code"}}},
+		{"12 34 56", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"123--456", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"-123456", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"123456-", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"12345-", 5, "", map[string][]string{"k": {"must be a 5-digit code"}}},
+		{"123 -456", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"123- 456", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+		{"12345a", 6, "", map[string][]string{"k": {"must be a 6-digit code"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.OTP("k", tt.in, tt.digits)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("no panic")
+			}
+		}()
+		v := New()
+		v.OTP("k", "123456", 0)
+	})
+}
+
+func TestPIN(t *testing.T) {
+	tests := []struct {
+		in         string
+		length     int
+		strong     bool
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", 4, true, "", make(map[string][]string)},
+		{"1111", 4, false, "1111", make(map[string][]string)},
+		{"1234", 4, false, "1234", make(map[string][]string)},
+		{"0000", 4, false, "0000", make(map[string][]string)},
+		{"3861", 4, true, "3861", make(map[string][]string)},
+		{"1235", 4, true, "1235", make(map[string][]string)},
+		{"902741", 6, true, "902741", make(map[string][]string)},
+		{"7", 1, true, "7", make(map[string][]string)},
+
+		{"123", 4, false, "", map[string][]string{"k": {"must be 4 digits"}}},
+		{"12345", 4, false, "", map[string][]string{"k": {"must be 4 digits"}}},
+		{"12a4", 4, false, "", map[string][]string{"k": {"must be 4 digits"}}},
+		{" 1234", 4, false, "", map[string][]string{"k": {"must be 4 digits"}}},
+		{"1111", 4, true, "", map[string][]string{"k": {"cannot be the same digit repeated"}}},
+		{"000000", 6, true, "", map[string][]string{"k": {"cannot be the same digit repeated"}}},
+		{"1234", 4, true, "", map[string][]string{"k": {"cannot be a sequence of digits"}}},
+		{"9876", 4, true, "", map[string][]string{"k": {"cannot be a sequence of digits"}}},
+		{"456789", 6, true, "", map[string][]string{"k": {"cannot be a sequence of digits"}}},
+		{"1212", 4, true, "", map[string][]string{"k": {"is too common"}}},
+		{"2580", 4, true, "", map[string][]string{"k": {"is too common"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			var out string
+			if tt.strong {
+				out = v.PINStrong("k", tt.in, tt.length)
+			} else {
+				out = v.PIN("k", tt.in, tt.length)
+			}
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       float64
+		wantErrors map[string][]string
+	}{
+		{"", 0, make(map[string][]string)},
+		{"0", 0, make(map[string][]string)},
+		{"100", 100, make(map[string][]string)},
+		{"12.5", 12.5, make(map[string][]string)},
+		{"12.5%", 12.5, make(map[string][]string)},
+		{" 12.5 % ", 12.5, make(map[string][]string)},
+
+		{"%", 0, map[string][]string{"k": {"must be a percentage between 0 and 100"}}},
+		{"-1", 0, map[string][]string{"k": {"must be a percentage between 0 and 100"}}},
+		{"100.1", 0, map[string][]string{"k": {"must be a percentage between 0 and 100"}}},
+		{"NaN", 0, map[string][]string{"k": {"must be a percentage between 0 and 100"}}},
+		{"12%%", 0, map[string][]string{"k": {"must be a percentage between 0 and 100"}}},
+		{"x", 0, map[string][]string{"k": {"must be a percentage between 0 and 100"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.Percent("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumeric(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"0", "0", make(map[string][]string)},
+		{"007", "007", make(map[string][]string)},
+		{"123456789012345678901234567890", "123456789012345678901234567890", make(map[string][]string)},
+		{"-1", "", map[string][]string{"k": {"must contain only digits"}}},
+		{" 1", "", map[string][]string{"k": {"must contain only digits"}}},
+		{"1.0", "", map[string][]string{"k": {"must contain only digits"}}},
+		{"١٢٣", "", map[string][]string{"k": {"must contain only digits"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.Numeric("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestSingleLineTrim(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"\n", "", make(map[string][]string)},
+		{"Hello", "Hello", make(map[string][]string)},
+		{"Hello\n", "Hello", make(map[string][]string)},
+		{"Hello\r\n", "Hello", make(map[string][]string)},
+		{"Hello\r", "Hello", make(map[string][]string)},
+		{"Hello \n", "Hello ", make(map[string][]string)},
+		{"Hello\n\n", "", map[string][]string{"k": {"must be a single line"}}},
+		{"Hello\r\n\r\n", "", map[string][]string{"k": {"must be a single line"}}},
+		{"Hello\nworld\n", "", map[string][]string{"k": {"must be a single line"}}},
+		{"Hello\u2028", "", map[string][]string{"k": {"must be a single line"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.SingleLineTrim("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxLines(t *testing.T) {
+	tests := []struct {
+		in         string
+		max        int
+		want       int
+		wantErrors map[string][]string
+	}{
+		{"", 1, 0, make(map[string][]string)},
+		{"\n", 1, 1, make(map[string][]string)},
+		{"a", 1, 1, make(map[string][]string)},
+		{"a\n", 1, 1, make(map[string][]string)},
+		{"a\r\n", 1, 1, make(map[string][]string)},
+		{"a\nb\nc\nd", 4, 4, make(map[string][]string)},
+		{"a\r\nb\r\nc\r\nd\r\n", 4, 4, make(map[string][]string)},
+		{"a\rb\rc\rd", 4, 4, make(map[string][]string)},
+		{"a\n\nb", 3, 3, make(map[string][]string)},
+		{"a\nb\n\n", 2, 3, map[string][]string{"k": {"must be at most 2 lines"}}},
+		{"a\r\nb\r\nc\r\nd\r\ne", 4, 5, map[string][]string{"k": {"must be at most 4 lines"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.MaxLines("k", tt.in, tt.max)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizedNFC(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"Martin", "Martin", make(map[string][]string)},
+		{"caf\u00e9", "caf\u00e9", make(map[string][]string)},
+		{"cafe\u0301", "caf\u00e9", map[string][]string{"k": {"must be in Unicode NFC form"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.NormalizedNFC("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	tests := []struct {
+		in              string
+		maxKeys, maxLen int
+		want            url.Values
+		wantErrors      map[string][]string
+	}{
+		{"", 0, 0, nil, make(map[string][]string)},
+		{"?", 0, 0, nil, make(map[string][]string)},
+		{"a=1&b=2", 0, 0, url.Values{"a": {"1"}, "b": {"2"}}, make(map[string][]string)},
+		{"?utm_source=x%20y", 0, 0, url.Values{"utm_source": {"x y"}}, make(map[string][]string)},
+		{"a=1&a=2", 1, 0, url.Values{"a": {"1", "2"}}, make(map[string][]string)},
+
+		{"a=%zz", 0, 0, nil, map[string][]string{"k": {"must be valid URL parameters"}}},
+		{"&&&", 0, 0, nil, map[string][]string{"k": {"must be valid URL parameters"}}},
+		{"a=1;b=2", 0, 0, nil, map[string][]string{"k": {"must be valid URL parameters"}}},
+		{"a=1&b=2&c=3", 2, 0, nil, map[string][]string{"k": {"must have at most 2 parameters"}}},
+		{"a=1&b=2", 0, 5, nil, map[string][]string{"k": {"must be shorter than 5 characters"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.QueryStringLimit("k", tt.in, tt.maxKeys, tt.maxLen)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if !reflect.DeepEqual(out, tt.want) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileExt(t *testing.T) {
+	allowed := []string{"png", ".JPG", "tar.gz"}
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"photo.png", "png", make(map[string][]string)},
+		{"Photo.PNG", "png", make(map[string][]string)},
+		{"photo.jpg", "jpg", make(map[string][]string)},
+		{`C:\Users\x\archive.TAR.GZ`, "tar.gz", make(map[string][]string)},
+
+		{"photo.gif", "", map[string][]string{"k": {"must have one of the extensions ‘png, .JPG, tar.gz’"}}},
+		{"png", "", map[string][]string{"k": {"must have one of the extensions ‘png, .JPG, tar.gz’"}}},
+		{".png", "", map[string][]string{"k": {"must have one of the extensions ‘png, .JPG, tar.gz’"}}},
+		{"png/photo", "", map[string][]string{"k": {"must have one of the extensions ‘png, .JPG, tar.gz’"}}},
+		{"photo.png.exe", "", map[string][]string{"k": {"must have one of the extensions ‘png, .JPG, tar.gz’"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.FileExt("k", tt.in, allowed)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoolean(t *testing.T) {
+	tests := []struct {
+		val        func(Validator) bool
+		want       bool
+		wantErrors map[string][]string
+	}{
+		{
+			func(v Validator) bool { return v.Boolean("k", "on") },
+			true,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "") },
+			false,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "0") },
+			false,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "n") },
+			false,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "no") },
+			false,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "f") },
+			false,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "false") },
+			false,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "FALSE") },
+			false,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "1") },
+			true,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "y") },
+			true,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "yes") },
+			true,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "t") },
+			true,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "true") },
+			true,
+			make(map[string][]string),
+		},
+		{
+			func(v Validator) bool { return v.Boolean("k", "TRUE") },
+			true,
+			make(map[string][]string),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			i := tt.val(v)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+
+			if i != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", i, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"xn--bcher-kva.example", []string{"bücher", "example"}},
+		{"www.example.com", []string{"www", "example", "com"}},
+		{"www.example.com.", []string{"www", "example", "com"}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.Domain("", tt.in)
+
+			if v.HasErrors() {
+				t.Fatal(v.Error())
+			}
+
+			if !reflect.DeepEqual(out, tt.want) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestHost(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"localhost", "localhost", make(map[string][]string)},
+		{" Example.COM. ", "example.com", make(map[string][]string)},
+		{"127.0.0.1", "127.0.0.1", make(map[string][]string)},
+		{"::1", "::1", make(map[string][]string)},
+		{"[2001:DB8::1]", "2001:db8::1", make(map[string][]string)},
+		{"example.com:80", "", map[string][]string{"k": {"must be a valid hostname or IP address"}}},
+		{"127.0.0.1/8", "", map[string][]string{"k": {"must be a valid hostname or IP address"}}},
+		{"exa mple.com", "", map[string][]string{"k": {"must be a valid hostname or IP address"}}},
+		{"[]", "", map[string][]string{"k": {"must be a valid hostname or IP address"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.Host("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %q\nwant: %q\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	tests := []struct {
+		in         string
+		wantHost   string
+		wantPort   int
+		wantErrors map[string][]string
+	}{
+		{"", "", 0, make(map[string][]string)},
+		{"db.example.com:5432", "db.example.com", 5432, make(map[string][]string)},
+		{"Localhost:1", "localhost", 1, make(map[string][]string)},
+		{"127.0.0.1:65535", "127.0.0.1", 65535, make(map[string][]string)},
+		{"[::1]:8080", "::1", 8080, make(map[string][]string)},
+		{"db.example.com", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+		{"db.example.com:", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+		{"db.example.com:0", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+		{"db.example.com:65536", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+		{"db.example.com:http", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+		{"::1:8080", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+		{"exa mple.com:80", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+		{":80", "", 0, map[string][]string{"k": {"must be a valid host and port, such as ‘example.com:8080’"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			host, port := v.HostPort("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if host != tt.wantHost || port != tt.wantPort {
+				t.Errorf("\nout:  %q %d\nwant: %q %d\n", host, port, tt.wantHost, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestEmailNormalized(t *testing.T) {
+	tests := []struct {
+		in         string
+		lowerLocal bool
+		want       mail.Address
+		wantErrors map[string][]string
+	}{
+		{"", false, mail.Address{}, make(map[string][]string)},
+		{"User@EXAMPLE.com", false, mail.Address{Address: "User@example.com"}, make(map[string][]string)},
+		{"User@EXAMPLE.com", true, mail.Address{Address: "user@example.com"}, make(map[string][]string)},
+		{"Martin <Martin@Example.COM>", false, mail.Address{Name: "Martin", Address: "Martin@example.com"}, make(map[string][]string)},
+		{"User@EXAMPLE", false, mail.Address{}, map[string][]string{"k": {"must be a valid email address"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.EmailNormalized("k", tt.in, tt.lowerLocal)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainASCII(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"www.EXAMPLE.com.", "www.example.com", make(map[string][]string)},
+		{"Bücher.example", "xn--bcher-kva.example", make(map[string][]string)},
+		{"xn--bcher-kva.EXAMPLE", "xn--bcher-kva.example", make(map[string][]string)},
+		{"bücher.xn--pgbg2dpr.ذبابة", "xn--bcher-kva.xn--pgbg2dpr.xn--mgbbbe5a", make(map[string][]string)},
+		{"-bücher.example", "", map[string][]string{"k": {"must be a valid domain: label can't start or end with a hyphen"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.DomainASCII("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       time.Time
+		wantErrors map[string][]string
+	}{
+		{"", time.Time{}, make(map[string][]string)},
+		{"2020-06-18T14:15:16Z", time.Date(2020, 6, 18, 14, 15, 16, 0, time.UTC), make(map[string][]string)},
+		{"2020-06-18T14:15:16.123Z", time.Date(2020, 6, 18, 14, 15, 16, 123000000, time.UTC), make(map[string][]string)},
+		{"2020-06-18T16:15:16+02:00", time.Date(2020, 6, 18, 14, 15, 16, 0, time.UTC), make(map[string][]string)},
+		{"2020-06-18", time.Time{}, map[string][]string{"k": {"must be a RFC 3339 timestamp"}}},
+		{"2020-06-18 14:15:16", time.Time{}, map[string][]string{"k": {"must be a RFC 3339 timestamp"}}},
+		{"2020-06-18T14:15:16", time.Time{}, map[string][]string{"k": {"must be a RFC 3339 timestamp"}}},
+		{"2020-13-18T14:15:16Z", time.Time{}, map[string][]string{"k": {"must be a RFC 3339 timestamp"}}},
+	}
 
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
 			v := New()
-			i := tt.val(v)
+			out := v.Timestamp("k", tt.in)
 
 			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
 				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
 			}
+			if !out.Equal(tt.want) {
+				t.Errorf("\nout:  %s\nwant: %s\n", out, tt.want)
+			}
+		})
+	}
+}
 
-			if i != tt.want {
-				t.Errorf("\nout:  %#v\nwant: %#v\n", i, tt.want)
+func TestRegexp(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"^a+$", "^a+$", make(map[string][]string)},
+		{`\d{3}-\w+`, `\d{3}-\w+`, make(map[string][]string)},
+		{"(a", "", map[string][]string{"k": {"must be a valid regular expression: missing closing ): `(a`"}}},
+		{"a**", "", map[string][]string{"k": {"must be a valid regular expression: invalid nested repetition operator: `**`"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.Regexp("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			var o string
+			if out != nil {
+				o = out.String()
+			}
+			if o != tt.want {
+				t.Errorf("\nout:  %q\nwant: %q\n", o, tt.want)
 			}
 		})
 	}
 }
 
-func TestDomain(t *testing.T) {
+func TestURLNormalized(t *testing.T) {
 	tests := []struct {
-		in   string
-		want []string
+		in         string
+		want       string
+		wantErrors map[string][]string
 	}{
-		{"xn--bcher-kva.example", []string{"bücher", "example"}},
-		{"www.example.com", []string{"www", "example", "com"}},
-		{"www.example.com.", []string{"www", "example", "com"}},
+		{"", "", make(map[string][]string)},
+		{"HTTP://Example.COM:80/./a/../b", "http://example.com/b", make(map[string][]string)},
+		{"http://example.com/b", "http://example.com/b", make(map[string][]string)},
+		{"https://example.com:443", "https://example.com/", make(map[string][]string)},
+		{"https://example.com:80/", "https://example.com:80/", make(map[string][]string)},
+		{"http://[::1]:80/x", "http://[::1]/x", make(map[string][]string)},
+		{"example.com/a/b/..", "http://example.com/a/", make(map[string][]string)},
+		{"http://example.com/../../a/./b/.", "http://example.com/a/b/", make(map[string][]string)},
+		{"http://example.com/a%2Fb/../c?", "http://example.com/c", make(map[string][]string)},
+		{"http://example.com/a%2Fb?q=A#", "http://example.com/a%2Fb?q=A", make(map[string][]string)},
+		{"http://example.com/Path#Frag", "http://example.com/Path#Frag", make(map[string][]string)},
+		{"HTTP://X", "", map[string][]string{"k": {"must be a valid url"}}},
 	}
 
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
 			v := New()
-			out := v.Domain("", tt.in)
+			out := v.URLNormalized("k", tt.in)
 
-			if v.HasErrors() {
-				t.Fatal(v.Error())
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			var got string
+			if out != nil {
+				got = out.String()
+			}
+			if got != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", got, tt.want)
 			}
+		})
+	}
+}
 
-			if !reflect.DeepEqual(out, tt.want) {
+func TestColorFunc(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       [4]uint8
+		wantErrors map[string][]string
+	}{
+		{"", [4]uint8{}, make(map[string][]string)},
+		{"rgb(255, 0, 0)", [4]uint8{255, 0, 0, 255}, make(map[string][]string)},
+		{"RGB( 255 , 0 , 0 )", [4]uint8{255, 0, 0, 255}, make(map[string][]string)},
+		{"rgba(0, 128, 255, 0.5)", [4]uint8{0, 128, 255, 128}, make(map[string][]string)},
+		{"rgb(0 128 255)", [4]uint8{0, 128, 255, 255}, make(map[string][]string)},
+		{"rgb(0 128 255 / 50%)", [4]uint8{0, 128, 255, 128}, make(map[string][]string)},
+		{"rgb(100%, 0%, 50%)", [4]uint8{255, 0, 128, 255}, make(map[string][]string)},
+		{"hsl(120, 100%, 50%)", [4]uint8{0, 255, 0, 255}, make(map[string][]string)},
+		{"hsl(120deg 100% 25%)", [4]uint8{0, 128, 0, 255}, make(map[string][]string)},
+		{"hsla(0, 0%, 100%, 0)", [4]uint8{255, 255, 255, 0}, make(map[string][]string)},
+		{"hsl(240 100% 50% / 1)", [4]uint8{0, 0, 255, 255}, make(map[string][]string)},
+
+		{"#fff", [4]uint8{}, map[string][]string{"k": {"must be a valid color"}}},
+		{"rgb(1, 2)", [4]uint8{}, map[string][]string{"k": {"must be a valid color"}}},
+		{"rgb(1, 2, 3", [4]uint8{}, map[string][]string{"k": {"must be a valid color"}}},
+		{"rgb(1 2 3 4)", [4]uint8{}, map[string][]string{"k": {"must be a valid color"}}},
+		{"rgb(1, 2, 3 / 1)", [4]uint8{}, map[string][]string{"k": {"must be a valid color"}}},
+		{"cmyk(1, 2, 3)", [4]uint8{}, map[string][]string{"k": {"must be a valid color"}}},
+		{"rgb(a, b, c)", [4]uint8{}, map[string][]string{"k": {"must be a valid color"}}},
+		{"rgb(256, 0, 0)", [4]uint8{}, map[string][]string{"k": {"red component must be 0-255"}}},
+		{"rgb(0, -1, 0)", [4]uint8{}, map[string][]string{"k": {"green component must be 0-255"}}},
+		{"rgb(0, 0, 101%)", [4]uint8{}, map[string][]string{"k": {"blue component must be 0-100%"}}},
+		{"rgba(0, 0, 0, 2)", [4]uint8{}, map[string][]string{"k": {"alpha component must be 0-1"}}},
+		{"hsl(361, 0%, 0%)", [4]uint8{}, map[string][]string{"k": {"hue component must be 0-360"}}},
+		{"hsl(0, 120%, 0%)", [4]uint8{}, map[string][]string{"k": {"saturation component must be 0-100%"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			r, g, b, a := v.ColorFunc("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if have := [4]uint8{r, g, b, a}; have != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", have, tt.want)
+			}
+		})
+	}
+
+	t.Run("message", func(t *testing.T) {
+		v := New()
+		v.ColorFunc("k", "rgb(256, 0, 0)", "foo")
+		want := map[string][]string{"k": {"foo"}}
+		if !reflect.DeepEqual(v.Errors, want) {
+			t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, want)
+		}
+	})
+}
+
+func TestPasswordHash(t *testing.T) {
+	var (
+		bcrypt   = "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+		argon2id = "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG"
+		scrypt   = "$scrypt$ln=16,r=8,p=1$aM15713r3Xsvxbi31lqr1Q$nFNh2CVHVjNldFVKDHDlm4CbdRSCdEBsjjJxD+iCs5E"
+	)
+
+	tests := []struct {
+		in         string
+		algs       []string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", nil, "", make(map[string][]string)},
+		{bcrypt, nil, "bcrypt", make(map[string][]string)},
+		{strings.Replace(bcrypt, "2a", "2b", 1), nil, "bcrypt", make(map[string][]string)},
+		{strings.Replace(bcrypt, "2a", "2y", 1), []string{"bcrypt"}, "bcrypt", make(map[string][]string)},
+		{argon2id, nil, "argon2id", make(map[string][]string)},
+		{argon2id, []string{"bcrypt", "argon2id"}, "argon2id", make(map[string][]string)},
+		{strings.Replace(argon2id, "v=19$", "", 1), nil, "argon2id", make(map[string][]string)},
+		{strings.Replace(argon2id, "argon2id", "argon2i", 1), nil, "argon2i", make(map[string][]string)},
+		{scrypt, nil, "scrypt", make(map[string][]string)},
+
+		{"password", nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{"$", nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{"$md5$asd", nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{bcrypt, []string{"argon2id"}, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{bcrypt[:59], nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(bcrypt, "$10$", "$99$", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(bcrypt, "$10$", "$1x$", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(bcrypt, "N9qo", "N9q!", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(argon2id, "m=65536,t=3,p=4", "t=3,m=65536,p=4", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(argon2id, "p=4", "p=0", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(argon2id, "v=19", "v=x", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(argon2id, "c29tZXNhbHQ", "c29tZQ", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(argon2id, "c29tZXNhbHQ", "c29tZXNhbHQ=", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{argon2id + "$", nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+		{strings.Replace(scrypt, "ln=16", "n=16", 1), nil, "", map[string][]string{"k": {"must be a supported password hash"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			out := v.PasswordHash("k", tt.in, tt.algs)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	tests := []struct {
+		in            string
+		unix, windows bool
+	}{
+		{"a", true, true},
+		{"/etc/passwd", true, true},
+		{"../a b/c.txt", true, true},
+		{`C:\Users\a.txt`, true, true},
+		{`\\?\C:\Users\a.txt`, true, true},
+		{"a\x00b", false, false},
+		{"ab:c", true, false},
+		{"1:b", true, false},
+		{"a?.txt", true, false},
+		{"a*.txt", true, false},
+		{`a"b`, true, false},
+		{"a<b>", true, false},
+		{"a|b", true, false},
+		{"a\tb", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if out := validFilePath(tt.in, false); out != tt.unix {
+				t.Errorf("unix: %t", out)
+			}
+			if out := validFilePath(tt.in, true); out != tt.windows {
+				t.Errorf("windows: %t", out)
+			}
+		})
+	}
+
+	v := New()
+	v.FilePath("a", "")
+	v.FilePath("b", "a\x00b")
+	v.FilePath("c", "a\x00b", "foo")
+	want := map[string][]string{"b": {"must be a valid file path"}, "c": {"foo"}}
+	if !reflect.DeepEqual(v.Errors, want) {
+		t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, want)
+	}
+}
+
+func TestSafePath(t *testing.T) {
+	tests := []struct {
+		in         string
+		depth, len int
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", 0, 0, "", make(map[string][]string)},
+		{"a", 0, 0, "a", make(map[string][]string)},
+		{"a/b/c.txt", 0, 0, "a/b/c.txt", make(map[string][]string)},
+		{"./a/./b", 0, 0, "a/b", make(map[string][]string)},
+		{"%2e%2e", 0, 0, "%2e%2e", make(map[string][]string)},
+		{"%2e%2e/%2e%2e/etc/passwd", 0, 0, "%2e%2e/%2e%2e/etc/passwd", make(map[string][]string)},
+		{"...", 0, 0, "...", make(map[string][]string)},
+		{"a..b/c", 0, 0, "a..b/c", make(map[string][]string)},
+		{"a/b/c", 3, 5, "a/b/c", make(map[string][]string)},
+
+		{"/etc/passwd", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"..", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{".", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"a/../../etc/passwd", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"a/../b", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"a/..", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{`..\..`, 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{`a\b`, 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{`C:\Windows`, 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"a//b", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"a/", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"a\x00b", 0, 0, "", map[string][]string{"k": {"must be a relative path"}}},
+		{"a/b/c", 2, 0, "", map[string][]string{"k": {"cannot be more than 2 levels deep"}}},
+		{"a/b/c", 0, 4, "", map[string][]string{"k": {"must be shorter than 4 characters"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.SafePathLimit("k", tt.in, tt.depth, tt.len)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountryCode(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"NL", "NL", make(map[string][]string)},
+		{"nl", "NL", make(map[string][]string)},
+		{" us ", "US", make(map[string][]string)},
+		{"AD", "AD", make(map[string][]string)},
+		{"ZW", "ZW", make(map[string][]string)},
+
+		{"XX", "", map[string][]string{"k": {"must be a valid country code"}}},
+		{"NLD", "", map[string][]string{"k": {"must be a valid country code"}}},
+		{"N", "", map[string][]string{"k": {"must be a valid country code"}}},
+		{"UK", "", map[string][]string{"k": {"must be a valid country code"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.CountryCode("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubdivision(t *testing.T) {
+	tests := []struct {
+		in, country string
+		want        string
+		wantErrors  map[string][]string
+	}{
+		{"", "", "", make(map[string][]string)},
+		{"US-CA", "", "US-CA", make(map[string][]string)},
+		{"nl-nh", "", "NL-NH", make(map[string][]string)},
+		{" GB-ENG ", "", "GB-ENG", make(map[string][]string)},
+		{"US-CA", "US", "US-CA", make(map[string][]string)},
+		{"US-CA", "us", "US-CA", make(map[string][]string)},
+
+		{"US-XX", "", "", map[string][]string{"k": {"must be a valid subdivision code"}}},
+		{"US", "", "", map[string][]string{"k": {"must be a valid subdivision code"}}},
+		{"CA", "", "", map[string][]string{"k": {"must be a valid subdivision code"}}},
+		{"US-CA", "NL", "", map[string][]string{"k": {"must be a valid subdivision code"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in+"/"+tt.country, func(t *testing.T) {
+			v := New()
+			out := v.SubdivisionOf("k", tt.in, tt.country)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		amount, currency string
+		signed           bool
+		want             int64
+		wantCurrency     string
+		wantErrors       map[string][]string
+	}{
+		{"", "", false, 0, "", make(map[string][]string)},
+		{"12.50", "EUR", false, 1250, "EUR", make(map[string][]string)},
+		{"12.5", "eur", false, 1250, "EUR", make(map[string][]string)},
+		{"12", "USD", false, 1200, "USD", make(map[string][]string)},
+		{"0.01", "USD", false, 1, "USD", make(map[string][]string)},
+		{"10", "JPY", false, 10, "JPY", make(map[string][]string)},
+		{"1.234", "BHD", false, 1234, "BHD", make(map[string][]string)},
+		{"12.50 EUR", "", false, 1250, "EUR", make(map[string][]string)},
+		{" 12.50  eur ", "", false, 1250, "EUR", make(map[string][]string)},
+		{"-12.50", "EUR", true, -1250, "EUR", make(map[string][]string)},
+
+		{"10.555", "USD", false, 0, "", map[string][]string{"k": {"must have at most 2 decimal places"}}},
+		{"10.5", "JPY", false, 0, "", map[string][]string{"k": {"must have at most 0 decimal places"}}},
+		{"-12.50", "EUR", false, 0, "", map[string][]string{"k": {"cannot be negative"}}},
+		{"12.50", "ABC", false, 0, "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"12.50", "", false, 0, "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"1", "XXX", false, 0, "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"1", "XTS", false, 0, "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"1 xau", "", false, 0, "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"-1", "XDR", true, 0, "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"12.", "EUR", false, 0, "", map[string][]string{"k": {"must be a valid amount"}}},
+		{".5", "EUR", false, 0, "", map[string][]string{"k": {"must be a valid amount"}}},
+		{"1,000", "EUR", false, 0, "", map[string][]string{"k": {"must be a valid amount"}}},
+		{"1e3", "EUR", false, 0, "", map[string][]string{"k": {"must be a valid amount"}}},
+		{"--1", "EUR", true, 0, "", map[string][]string{"k": {"must be a valid amount"}}},
+		{"99999999999999999999", "EUR", false, 0, "", map[string][]string{"k": {"must be a valid amount"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			v := New()
+			var (
+				out      int64
+				currency string
+			)
+			if tt.signed {
+				out, currency = v.MoneySigned("k", tt.amount, tt.currency)
+			} else {
+				out, currency = v.Money("k", tt.amount, tt.currency)
+			}
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want || currency != tt.wantCurrency {
+				t.Errorf("\nout:  %#v %#v\nwant: %#v %#v\n", out, currency, tt.want, tt.wantCurrency)
+			}
+		})
+	}
+}
+
+func TestABARoutingNumber(t *testing.T) {
+	tests := []struct {
+		in         string
+		internal   bool
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", false, "", make(map[string][]string)},
+		{"011000015", false, "011000015", make(map[string][]string)},
+		{"021000021", false, "021000021", make(map[string][]string)},
+		{" 122105278 ", false, "122105278", make(map[string][]string)},
+
+		{"021000022", false, "", map[string][]string{"k": {"must be a valid routing number"}}},
+		{"02100002", false, "", map[string][]string{"k": {"must be a valid routing number"}}},
+		{"0210000210", false, "", map[string][]string{"k": {"must be a valid routing number"}}},
+		{"02100002x", false, "", map[string][]string{"k": {"must be a valid routing number"}}},
+		{"021-000-021", false, "", map[string][]string{"k": {"must be a valid routing number"}}},
+		{"500000005", false, "", map[string][]string{"k": {"must be a valid routing number"}}},
+
+		// Internal use.
+		{"500000005", true, "500000005", make(map[string][]string)},
+		{"021000021", true, "021000021", make(map[string][]string)},
+		{"500000006", true, "", map[string][]string{"k": {"must be a valid routing number"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s-%t", tt.in, tt.internal), func(t *testing.T) {
+			v := New()
+			var out string
+			if tt.internal {
+				out = v.ABARoutingNumberInternal("k", tt.in)
+			} else {
+				out = v.ABARoutingNumber("k", tt.in)
+			}
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortCode(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"123456", "123456", make(map[string][]string)},
+		{"12-34-56", "123456", make(map[string][]string)},
+		{" 12 34 56 ", "123456", make(map[string][]string)},
+
+		{"000000", "", map[string][]string{"k": {"must be a valid sort code"}}},
+		{"00-00-00", "", map[string][]string{"k": {"must be a valid sort code"}}},
+		{"12345", "", map[string][]string{"k": {"must be a valid sort code"}}},
+		{"1234567", "", map[string][]string{"k": {"must be a valid sort code"}}},
+		{"12-34 56", "", map[string][]string{"k": {"must be a valid sort code"}}},
+		{"12/34/56", "", map[string][]string{"k": {"must be a valid sort code"}}},
+		{"1-23-456", "", map[string][]string{"k": {"must be a valid sort code"}}},
+		{"ab-cd-ef", "", map[string][]string{"k": {"must be a valid sort code"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.SortCode("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"de", "de", make(map[string][]string)},
+		{"en_US", "en-US", make(map[string][]string)},
+		{"pt-BR", "pt-BR", make(map[string][]string)},
+		{" EN_us ", "en-US", make(map[string][]string)},
+		{"fil-PH", "fil-PH", make(map[string][]string)},
+
+		{"xx", "", map[string][]string{"k": {"must be a valid locale"}}},
+		{"en-XX", "", map[string][]string{"k": {"must be a valid locale"}}},
+		{"en-", "", map[string][]string{"k": {"must be a valid locale"}}},
+		{"en--US", "", map[string][]string{"k": {"must be a valid locale"}}},
+		{"-", "", map[string][]string{"k": {"must be a valid locale"}}},
+		{"zh-Hant-TW", "", map[string][]string{"k": {"must be a valid locale"}}},
+		{"english", "", map[string][]string{"k": {"must be a valid locale"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.Locale("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "", make(map[string][]string)},
+		{"EUR", "EUR", make(map[string][]string)},
+		{"usd", "USD", make(map[string][]string)},
+		{" jpy ", "JPY", make(map[string][]string)},
+		{"XAU", "XAU", make(map[string][]string)},
+
+		{"USDD", "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"US", "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"XYZ", "", map[string][]string{"k": {"must be a valid currency code"}}},
+		{"$", "", map[string][]string{"k": {"must be a valid currency code"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.Currency("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{"", "", ""},
+		{"en", "en", ""},
+		{"EN", "en", ""},
+		{"en-US", "en-US", ""},
+		{"en_us", "en-US", ""},
+		{"zh-Hant", "zh-Hant", ""},
+		{"zh-hant-tw", "zh-Hant-TW", ""},
+		{"es-419", "es-419", ""},
+		{"zh-yue-HK", "zh-yue-HK", ""},
+		{"sl-rozaj-biske", "sl-rozaj-biske", ""},
+		{"de-CH-1996", "de-CH-1996", ""},
+		{"en-US-u-islamcal", "en-US-u-islamcal", ""},
+		{"en-a-bbb-x-a-CCC", "en-a-bbb-x-a-ccc", ""},
+		{"x-whatever", "x-whatever", ""},
+		{"i-klingon", "i-klingon", ""},
+		{"EN-gb-OED", "en-GB-oed", ""},
+		{"iw", "iw", ""},
+		{"qaa-Qaaa-QM", "qaa-Qaaa-QM", ""},
+
+		{"e", "", "must be a valid language tag: invalid language subtag"},
+		{"123", "", "must be a valid language tag: invalid language subtag"},
+		{"en-", "", "must be a valid language tag: invalid subtag"},
+		{"en--US", "", "must be a valid language tag: invalid subtag"},
+		{"en US", "", "must be a valid language tag: invalid subtag"},
+		{"en-US-US", "", "must be a valid language tag: invalid subtag"},
+		{"en-Latn-Latn", "", "must be a valid language tag: invalid subtag"},
+		{"de-1996-1996", "", "must be a valid language tag: duplicate variant subtag"},
+		{"en-a-bbb-a-ccc", "", "must be a valid language tag: duplicate extension"},
+		{"en-a", "", "must be a valid language tag: empty extension"},
+		{"en-x", "", "must be a valid language tag: empty private use subtag"},
+		{"x", "", "must be a valid language tag: empty private use subtag"},
+		{"abcdefghi", "", "must be a valid language tag: invalid subtag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.Language("k", tt.in)
+
+			if have := strings.Join(v.Errors["k"], ", "); have != tt.wantErr {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", have, tt.wantErr)
+			}
+			if out != tt.want {
 				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
 			}
 		})
 	}
 }
+
+func TestTimezone(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantErrors map[string][]string
+	}{
+		{"", "<nil>", make(map[string][]string)},
+		{"UTC", "UTC", make(map[string][]string)},
+		{"Europe/Amsterdam", "Europe/Amsterdam", make(map[string][]string)},
+		{"America/New_York", "America/New_York", make(map[string][]string)},
+
+		{"Local", "<nil>", map[string][]string{"k": {"must be a valid timezone"}}},
+		{"Mars/Olympus_Mons", "<nil>", map[string][]string{"k": {"must be a valid timezone"}}},
+		{"europe/amsterdam ", "<nil>", map[string][]string{"k": {"must be a valid timezone"}}},
+		{"../../etc/passwd", "<nil>", map[string][]string{"k": {"must be a valid timezone"}}},
+		{"/etc/localtime", "<nil>", map[string][]string{"k": {"must be a valid timezone"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v := New()
+			out := v.Timezone("k", tt.in)
+
+			if !reflect.DeepEqual(v.Errors, tt.wantErrors) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", v.Errors, tt.wantErrors)
+			}
+			have := "<nil>"
+			if out != nil {
+				have = out.String()
+			}
+			if have != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", have, tt.want)
+			}
+		})
+	}
+}
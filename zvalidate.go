@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"html/template"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // Validator hold the validation errors.
@@ -17,14 +19,136 @@ import (
 // Typically you shouldn't create this directly but use the New() function.
 type Validator struct {
 	Errors map[string][]string `json:"errors"`
+
+	// Machine-readable codes for the errors; Codes[key][i] is the code for
+	// Errors[key][i]. The code may be blank.
+	//
+	// The built-in validators use the message ID as the code, such as
+	// "required" or "len_longer"; see Catalog.
+	Codes map[string][]string `json:"codes,omitempty"`
+
+	// Warnings are reported, but don't count as errors for HasErrors(),
+	// ErrorOrNil(), etc.; see Warn().
+	Warnings map[string][]string `json:"warnings,omitempty"`
+
+	// Messages to use instead of the package defaults (MessageRequired, etc.),
+	// for example from Catalogs.
+	Messages Catalog `json:"-"`
+
+	fields    []Field
+	onlyFirst bool
+	mu        *sync.Mutex
+
+	parent *Validator // Add errors to parent instead; see Prefixed().
+	prefix string
+	skip   bool // Don't add any errors; see If().
+}
+
+// Field is an error for a single key, with the rule that failed and its
+// parameters.
+//
+// The JSON form is:
+//
+//   {"key": "name", "rule": "len_longer", "params": {"min": 2}, "message": "must be longer than 2 characters"}
+//
+// Params is omitted if there are no parameters, and the Rule is the same as the
+// code in Validator.Codes.
+type Field struct {
+	Key     string                 `json:"key"`
+	Rule    string                 `json:"rule"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+	Message string                 `json:"message"`
+}
+
+// Option sets options for a Validator.
+type Option func(*Validator)
+
+// WithMessages uses the messages from c instead of the package defaults.
+//
+// This can be given more than once; later messages override earlier ones:
+//
+//   v := zvalidate.New(
+//       zvalidate.WithMessages(zvalidate.Catalogs["nl"]),
+//       zvalidate.WithMessages(zvalidate.Catalog{"required": "verplicht"}))
+func WithMessages(c Catalog) Option {
+	return func(v *Validator) {
+		if v.Messages == nil {
+			v.Messages = make(Catalog, len(c))
+		}
+		for k, m := range c {
+			v.Messages[k] = m
+		}
+	}
 }
 
 // New initializes a new Validator.
-func New() Validator {
-	return Validator{Errors: make(map[string][]string)}
+func New(opts ...Option) Validator {
+	v := Validator{
+		Errors: make(map[string][]string),
+		Codes:  make(map[string][]string),
+	}
+	for _, o := range opts {
+		o(&v)
+	}
+	return v
+}
+
+// NewConcurrent initializes a new Validator which can be used from multiple
+// goroutines.
+//
+// Adding errors is safe to do concurrently; reading the errors with String(),
+// HasErrors(), etc. should be done after all goroutines have finished. For
+// example:
+//
+//   v := zvalidate.NewConcurrent()
+//   var wg sync.WaitGroup
+//   for _, u := range urls {
+//       wg.Add(1)
+//       go func(u string) {
+//           defer wg.Done()
+//           checkReachable(&v, u)
+//       }(u)
+//   }
+//   wg.Wait()
+//   return v.ErrorOrNil()
+func NewConcurrent(opts ...Option) Validator {
+	v := New(opts...)
+	v.mu = new(sync.Mutex)
+	return v
+}
+
+func (v *Validator) lock() {
+	if v.mu != nil {
+		v.mu.Lock()
+	}
 }
 
+func (v *Validator) unlock() {
+	if v.mu != nil {
+		v.mu.Unlock()
+	}
+}
+
+// ErrValidation is matched by errors.Is() for all Validator errors, for both
+// the pointer and value forms:
+//
+//   if errors.Is(err, zvalidate.ErrValidation) {
+//       w.WriteHeader(400)
+//   }
+var ErrValidation = errors.New("validation error")
+
 // As tries to convert this error to a Validator, returning nil if it's not.
+//
+// Both Validator and *Validator are converted, and wrapped errors are
+// unwrapped. The canonical way to return a Validator as an error is with
+// ErrorOrNil(), which returns a *Validator.
+//
+// The nil check doubles as the "ok" value:
+//
+//   if v := zvalidate.As(err); v != nil {
+//       w.WriteHeader(400)
+//       json.NewEncoder(w).Encode(v)
+//   }
 func As(err error) *Validator {
 	v := new(Validator)
 	if errors.As(err, v) || errors.As(err, &v) {
@@ -36,16 +160,175 @@ func As(err error) *Validator {
 // Error interface.
 func (v Validator) Error() string { return v.String() }
 
+// Is reports if target is ErrValidation.
+func (v Validator) Is(target error) bool { return target == ErrValidation }
+
 // Code returns the HTTP status code for the error. Satisfies the guru.coder
 // interface in zgo.at/guru.
 func (v Validator) Code() int { return 400 }
 
 // ErrorJSON for reporting errors as JSON.
+//
+// The keys are always sorted, and the errors for a key are in the order they
+// were added, so the output is the same every time.
 func (v Validator) ErrorJSON() ([]byte, error) { return json.Marshal(v) }
 
+// OnlyFirst makes the Validator record only the first error for every key;
+// errors for a key that already has an error are ignored.
+//
+// This prevents messages such as "must be set, must be a valid email address":
+//
+//   v := zvalidate.New()
+//   v.OnlyFirst()
+//   v.Required("email", email)
+//   v.Email("email", email) // Ignored if Required() failed.
+func (v *Validator) OnlyFirst() {
+	v.onlyFirst = true
+}
+
 // Append a new error.
+//
+// The value is used as a format string for fmt.Sprintf() only if format
+// arguments are given; otherwise it's used as-is.
 func (v *Validator) Append(key, value string, format ...interface{}) {
-	v.Errors[key] = append(v.Errors[key], fmt.Sprintf(value, format...))
+	v.AppendCode(key, "", value, format...)
+}
+
+// Warn appends a new warning.
+//
+// Warnings are reported in Warnings but aren't errors; this is useful to surface
+// problems without blocking the submission, for example before enforcing a new
+// validation.
+func (v *Validator) Warn(key, value string, format ...interface{}) {
+	if v.skip {
+		return
+	}
+	if v.parent != nil {
+		v.parent.Warn(v.prefix+key, value, format...)
+		return
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	if v.Warnings == nil {
+		v.Warnings = make(map[string][]string)
+	}
+	v.Warnings[key] = append(v.Warnings[key], sprintf(value, format...))
+}
+
+// Soft runs the validations in fn, but adds any errors as warnings. For
+// example:
+//
+//   v.Soft(func(v *zvalidate.Validator) {
+//       v.Len("name", user.Name, 0, 50) // Not enforced yet.
+//   })
+func (v *Validator) Soft(fn func(v *Validator)) {
+	soft := New(WithMessages(v.Messages))
+	fn(&soft)
+
+	for _, k := range soft.Keys() {
+		for _, e := range soft.Errors[k] {
+			v.Warn(k, "%s", e)
+		}
+	}
+	for k, w := range soft.Warnings {
+		for _, ww := range w {
+			v.Warn(k, "%s", ww)
+		}
+	}
+}
+
+// HasWarnings reports if this validation has any warnings.
+func (v *Validator) HasWarnings() bool {
+	for _, w := range v.Warnings {
+		if len(w) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendKey appends a new error, always using msg as-is, even if it looks like
+// a format string:
+//
+//   v.AppendKey(fmt.Sprintf("items[%d]", i), userMsg)
+func (v *Validator) AppendKey(key, msg string) {
+	v.appendField(key, "", nil, msg)
+}
+
+// AppendCode appends a new error with a machine-readable code, such as
+// "required" or "email".
+func (v *Validator) AppendCode(key, code, value string, format ...interface{}) {
+	v.appendField(key, code, nil, sprintf(value, format...))
+}
+
+// sprintf is like fmt.Sprintf(), but uses value as-is if there are no format
+// arguments, so that "50% off" isn't mangled.
+func sprintf(value string, format ...interface{}) string {
+	if len(format) == 0 {
+		return value
+	}
+	return fmt.Sprintf(value, format...)
+}
+
+func (v *Validator) appendField(key, code string, params map[string]interface{}, msg string) {
+	if v.skip {
+		return
+	}
+	if v.parent != nil {
+		v.parent.appendField(v.prefix+key, code, params, msg)
+		return
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	if v.onlyFirst && len(v.Errors[key]) > 0 {
+		return
+	}
+	if v.Codes == nil {
+		v.Codes = make(map[string][]string)
+	}
+
+	v.Codes[key] = append(v.codesFor(key), code)
+	v.Errors[key] = append(v.Errors[key], msg)
+	v.fields = append(v.fields, Field{Key: key, Rule: code, Params: params, Message: msg})
+}
+
+// Fields gets all errors in the order they were added, with the rule and
+// parameters.
+//
+// This only includes errors added with the Validator's methods, and not errors
+// added to Errors directly.
+func (v *Validator) Fields() []Field {
+	v.lock()
+	defer v.unlock()
+
+	f := make([]Field, len(v.fields))
+	copy(f, v.fields)
+	return f
+}
+
+// removeFields removes all fields for key.
+func (v *Validator) removeFields(key string) {
+	f := v.fields[:0]
+	for _, ff := range v.fields {
+		if ff.Key != key {
+			f = append(f, ff)
+		}
+	}
+	v.fields = f
+}
+
+// codesFor gets the codes for key, padded with blank codes so it's as long as
+// the list of errors.
+func (v *Validator) codesFor(key string) []string {
+	c := v.Codes[key]
+	for len(c) < len(v.Errors[key]) {
+		c = append(c, "")
+	}
+	return c
 }
 
 // Pop an error, removing all errors for this key.
@@ -56,18 +339,153 @@ func (v *Validator) Append(key, value string, format ...interface{}) {
 //
 // Returns nil if there are no errors for this key.
 func (v *Validator) Pop(key string) []string {
-	if len(v.Errors[key]) == 0 {
-		return nil
-	}
+	v.lock()
+	defer v.unlock()
 
 	errs := v.Errors[key]
 	delete(v.Errors, key)
+	delete(v.Codes, key)
+	v.removeFields(key)
+	if len(errs) == 0 {
+		return nil
+	}
 	return errs
 }
 
+// Delete all errors for this key.
+//
+// This is useful if a later check makes earlier errors irrelevant, for example
+// when the user chose to sign in with SSO there's no need to report errors for
+// the password.
+func (v *Validator) Delete(key string) {
+	v.lock()
+	defer v.unlock()
+
+	delete(v.Errors, key)
+	delete(v.Codes, key)
+	v.removeFields(key)
+}
+
 // HasErrors reports if this validation has any errors.
+//
+// Keys with an empty list of errors are not counted.
 func (v *Validator) HasErrors() bool {
-	return len(v.Errors) > 0
+	for _, e := range v.Errors {
+		if len(e) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasKey reports if there are any errors for this key.
+func (v *Validator) HasKey(key string) bool {
+	v.lock()
+	defer v.unlock()
+	return len(v.Errors[key]) > 0
+}
+
+// HasErrorsFor reports if there are any errors for this key; this is the same
+// as HasKey().
+func (v *Validator) HasErrorsFor(key string) bool {
+	return v.HasKey(key)
+}
+
+// HasErrorsUnder reports if there are any errors for prefix, or for any key
+// nested under it by Sub(), SubIndex(), Each(), or MergePrefix().
+//
+// For example "settings" matches "settings", "settings.domain", and
+// "settings[0].domain", but not "settingsx".
+func (v *Validator) HasErrorsUnder(prefix string) bool {
+	v.lock()
+	defer v.unlock()
+
+	for k, e := range v.Errors {
+		if len(e) == 0 {
+			continue
+		}
+		if k == prefix || strings.HasPrefix(k, prefix+".") || strings.HasPrefix(k, prefix+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// First gets the first error, sorted by key, or two blank strings if there are
+// no errors.
+//
+// This is useful for CLI tools or terse API responses where you want to display
+// just one error.
+func (v *Validator) First() (key, msg string) {
+	v.lock()
+	defer v.unlock()
+
+	first := false
+	for k, e := range v.Errors {
+		if len(e) > 0 && (!first || k < key) {
+			first, key, msg = true, k, e[0]
+		}
+	}
+	return key, msg
+}
+
+// FirstError gets the message of the first error, sorted by key, or a blank
+// string if there are no errors.
+func (v *Validator) FirstError() string {
+	_, msg := v.First()
+	return msg
+}
+
+// ErrorsFor gets a copy of the errors for this key.
+//
+// Returns nil if there are no errors for this key.
+func (v *Validator) ErrorsFor(key string) []string {
+	v.lock()
+	defer v.unlock()
+
+	if len(v.Errors[key]) == 0 {
+		return nil
+	}
+	e := make([]string, len(v.Errors[key]))
+	copy(e, v.Errors[key])
+	return e
+}
+
+// JoinedFor gets all errors for this key joined with sep, for example to
+// display them under a form input:
+//
+//   {{.Validate.JoinedFor "email" ", "}}
+//
+// Returns an empty string if there are no errors for this key.
+func (v *Validator) JoinedFor(key, sep string) string {
+	return strings.Join(v.ErrorsFor(key), sep)
+}
+
+// Count gets the total number of errors for all keys.
+func (v *Validator) Count() int {
+	v.lock()
+	defer v.unlock()
+
+	n := 0
+	for _, e := range v.Errors {
+		n += len(e)
+	}
+	return n
+}
+
+// Keys gets a sorted list of all keys with errors.
+func (v *Validator) Keys() []string {
+	v.lock()
+	defer v.unlock()
+
+	keys := make([]string, 0, len(v.Errors))
+	for k, e := range v.Errors {
+		if len(e) > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // ErrorOrNil returns nil if there are no errors, or the Validator object if
@@ -113,10 +531,12 @@ func (v *Validator) ErrorOrNil() error {
 //   // Keys will be added as "settings.domain" and "settings.email".
 //   v.Sub("settings", "", customer.validateSettings())
 //
-//   // List as array; keys will be added as "addresses[0].city" etc.
-//   for i, addr := range customer.Addresses {
-//       v.Sub("addresses", i, addr.Validate())
+//   // List as array; keys will be added as "addresses[home].city" etc.
+//   for name, addr := range customer.Addresses {
+//       v.Sub("addresses", name, addr.Validate())
 //   }
+//
+// Use SubIndex() for slices.
 func (v *Validator) Sub(key, subKey string, err error) {
 	if err == nil {
 		return
@@ -135,68 +555,277 @@ func (v *Validator) Sub(key, subKey string, err error) {
 		}
 		sub = &ss
 	}
-	if !sub.HasErrors() {
+	if !sub.HasErrors() && !sub.HasWarnings() {
 		return
 	}
 
-	for k, val := range sub.Errors {
-		mk := fmt.Sprintf("%s.%s", key, k)
-		v.Errors[mk] = append(v.Errors[mk], val...)
+	v.merge(key+".", *sub)
+}
+
+// SubIndex adds sub-validations for an index in a slice.
+//
+// This is like Sub(), but with an int index. For example:
+//
+//   // Keys will be added as "addresses[0].city" etc.
+//   for i, addr := range customer.Addresses {
+//       v.SubIndex("addresses", i, addr.Validate())
+//   }
+//
+// A negative index means there is no index, and is the same as Sub(key, "",
+// err); keys will be added as "addresses.city".
+func (v *Validator) SubIndex(key string, index int, err error) {
+	if index < 0 {
+		v.Sub(key, "", err)
+		return
+	}
+	v.Sub(key, strconv.Itoa(index), err)
+}
+
+// Each runs fn for every value, with the key as "key[i]".
+//
+// For example, to validate a list of email addresses:
+//
+//   v.Each("emails", emails, func(v *zvalidate.Validator, key, value string) {
+//       v.Email(key, value)
+//   })
+//
+// Errors will be added as "emails[0]", "emails[1]", etc.
+func (v *Validator) Each(key string, values []string, fn func(v *Validator, key, value string)) {
+	for i, val := range values {
+		fn(v, fmt.Sprintf("%s[%d]", key, i), val)
+	}
+}
+
+// CSV splits a comma-separated value such as "a, b,c" and runs fn for every
+// token with Each(). The tokens are trimmed of whitespace, and returned.
+//
+// It will add an error to key and not run fn if there are more than max tokens;
+// use 0 for no maximum.
+func (v *Validator) CSV(key, value string, max int, fn func(v *Validator, key, token string), message ...string) []string {
+	if value == "" {
+		return nil
+	}
+
+	tokens := strings.Split(value, ",")
+	if max > 0 && len(tokens) > max {
+		v.appendParams(key, "csv_max", map[string]interface{}{"max": max}, message, max)
+		return nil
+	}
+
+	for i := range tokens {
+		tokens[i] = strings.TrimSpace(tokens[i])
+	}
+	v.Each(key, tokens, fn)
+	return tokens
+}
+
+// When runs the validations in fn only if cond is true.
+//
+// The validations operate on the same Validator. For example:
+//
+//   v.When(order.Paid, func(v *zvalidate.Validator) {
+//       v.Required("billing_address", order.BillingAddress)
+//   })
+func (v *Validator) When(cond bool, fn func(v *Validator)) {
+	if cond {
+		fn(v)
+	}
+}
+
+// If gets a Validator which adds errors to v only if cond is true; if cond is
+// false all validations are ignored. For example:
+//
+//   v.If(isEU).Required("vat_number", f.VAT)
+//
+// This is like When(), but more convenient for a single validation. The
+// returned Validator only adds errors: use v to read or remove them.
+func (v *Validator) If(cond bool) *Validator {
+	return &Validator{
+		Messages: v.Messages,
+		parent:   v,
+		skip:     !cond,
 	}
 }
 
+// Unless is like If(), but adds errors only if cond is false.
+func (v *Validator) Unless(cond bool) *Validator {
+	return v.If(!cond)
+}
+
 // Merge errors from another validator in to this one.
 func (v *Validator) Merge(other Validator) {
+	v.merge("", other)
+}
+
+// MergePrefix merges errors from another validator in to this one, adding
+// prefix to all the keys as "prefix.key".
+//
+// This is useful to merge validators which may have the same keys, for example:
+//
+//   v.MergePrefix("billing", billing.Validate())
+//   v.MergePrefix("shipping", shipping.Validate())
+func (v *Validator) MergePrefix(prefix string, other Validator) {
+	v.merge(prefix+".", other)
+}
+
+// Prefix adds prefix to all the keys as "prefix.key".
+//
+// This is useful to namespace the errors of a component before returning them,
+// for example:
+//
+//   v.Required("city", a.City)
+//   v.Prefix("shipping") // Key is now "shipping.city"
+//   return v.ErrorOrNil()
+func (v *Validator) Prefix(prefix string) {
+	v.lock()
+	defer v.unlock()
+
+	errs := make(map[string][]string, len(v.Errors))
+	codes := make(map[string][]string, len(v.Codes))
+	for k, val := range v.Errors {
+		errs[prefix+"."+k] = val
+	}
+	for k, val := range v.Codes {
+		codes[prefix+"."+k] = val
+	}
+	v.Errors, v.Codes = errs, codes
+	if v.Warnings != nil {
+		warn := make(map[string][]string, len(v.Warnings))
+		for k, val := range v.Warnings {
+			warn[prefix+"."+k] = val
+		}
+		v.Warnings = warn
+	}
+	for i := range v.fields {
+		v.fields[i].Key = prefix + "." + v.fields[i].Key
+	}
+}
+
+// Prefixed gets a Validator which adds all errors to v with the keys prefixed
+// as "prefix.key".
+//
+// This is useful to validate several objects in one Validator without passing
+// the prefix to every call:
+//
+//   billing := v.Prefixed("billing")
+//   billing.Required("city", order.Billing.City) // Added as "billing.city".
+//
+// Prefixed validators can be nested, giving "billing.address.city". The
+// returned Validator only adds errors: use v to read or remove them.
+func (v *Validator) Prefixed(prefix string) *Validator {
+	return &Validator{
+		Messages: v.Messages,
+		parent:   v,
+		prefix:   prefix + ".",
+	}
+}
+
+func (v *Validator) merge(prefix string, other Validator) {
+	if v.skip {
+		return
+	}
+	if v.parent != nil {
+		v.parent.merge(v.prefix+prefix, other)
+		return
+	}
+
+	v.lock()
+	defer v.unlock()
+
+	if v.Codes == nil {
+		v.Codes = make(map[string][]string)
+	}
+	// With OnlyFirst we only want the first error for keys that don't have
+	// any errors yet.
+	skip := make(map[string]bool)
 	for k, val := range other.Errors {
-		v.Errors[k] = append(v.Errors[k], val...)
+		mk := prefix + k
+		codes := other.codesFor(k)
+		if v.onlyFirst {
+			if len(v.Errors[mk]) > 0 || len(val) == 0 {
+				skip[mk] = true
+				continue
+			}
+			val, codes = val[:1], codes[:1]
+		}
+		v.Codes[mk] = append(v.codesFor(mk), codes...)
+		v.Errors[mk] = append(v.Errors[mk], val...)
+	}
+	for _, f := range other.fields {
+		f.Key = prefix + f.Key
+		if v.onlyFirst {
+			if skip[f.Key] {
+				continue
+			}
+			skip[f.Key] = true
+		}
+		v.fields = append(v.fields, f)
+	}
+	for k, val := range other.Warnings {
+		if v.Warnings == nil {
+			v.Warnings = make(map[string][]string)
+		}
+		v.Warnings[prefix+k] = append(v.Warnings[prefix+k], val...)
 	}
 }
 
 // Strings representation of all errors, or a blank string if there are none.
+//
+// Every key is on its own line and ends with a period, e.g.:
+//
+//   k: oh no, more.
+//   k2: asd.
 func (v *Validator) String() string {
 	if !v.HasErrors() {
 		return ""
 	}
+	return v.Format(".\n", ": ") + ".\n"
+}
 
-	// Make sure the order is always the same.
-	keys := make([]string, len(v.Errors))
-	i := 0
-	for k := range v.Errors {
-		keys[i] = k
-		i++
+// Format all errors as a string, or a blank string if there are none.
+//
+// Keys are joined with sep, and kvSep is added between the key and the errors
+// for that key. For example Format("; ", ": ") gives a single line suitable for
+// logging:
+//
+//   k: oh no, more; k2: asd
+func (v *Validator) Format(sep, kvSep string) string {
+	if !v.HasErrors() {
+		return ""
 	}
-	sort.Strings(keys)
 
 	var b strings.Builder
-	for _, k := range keys {
+	for i, k := range v.Keys() {
+		if i > 0 {
+			b.WriteString(sep)
+		}
 		if k != "" {
 			b.WriteString(k)
-			b.WriteString(": ")
+			b.WriteString(kvSep)
 		}
 		b.WriteString(strings.Join(v.Errors[k], ", "))
-		b.WriteString(".\n")
 	}
 	return b.String()
 }
 
 // HTML representation of all errors, or a blank string if there are none.
-func (v *Validator) HTML() template.HTML {
+//
+// The keys and errors are escaped, so this is safe to use in templates:
+//
+//   <ul class='zvalidate'>
+//   <li><strong>k</strong>: oh no, more.</li>
+//   <li><strong>k2</strong>: asd.</li>
+//   </ul>
+//
+// The class can be changed with opts.
+func (v *Validator) HTML(opts ...HTMLOptions) template.HTML {
 	if !v.HasErrors() {
 		return ""
 	}
 
-	// Make sure the order is always the same.
-	keys := make([]string, len(v.Errors))
-	i := 0
-	for k := range v.Errors {
-		keys[i] = k
-		i++
-	}
-	sort.Strings(keys)
-
 	var b strings.Builder
-	b.WriteString("<ul class='zvalidate'>\n")
-	for _, k := range keys {
+	b.WriteString(htmlOpen(opts))
+	for _, k := range v.Keys() {
 		b.WriteString("<li>")
 		if k != "" {
 			b.WriteString(fmt.Sprintf("<strong>%s</strong>: ", template.HTMLEscapeString(k)))
@@ -207,3 +836,40 @@ func (v *Validator) HTML() template.HTML {
 	b.WriteString("</ul>\n")
 	return template.HTML(b.String())
 }
+
+// HTMLFor renders the errors for key as a list, or a blank string if there are
+// none:
+//
+//   <ul class='zvalidate'>
+//   <li>must be set.</li>
+//   </ul>
+//
+// This is useful to display the errors next to a form input. The errors are
+// escaped, and the class can be changed with opts.
+func (v *Validator) HTMLFor(key string, opts ...HTMLOptions) template.HTML {
+	errs := v.ErrorsFor(key)
+	if len(errs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(htmlOpen(opts))
+	for _, e := range errs {
+		b.WriteString(fmt.Sprintf("<li>%s.</li>\n", template.HTMLEscapeString(e)))
+	}
+	b.WriteString("</ul>\n")
+	return template.HTML(b.String())
+}
+
+// HTMLOptions are options for HTML() and HTMLFor().
+type HTMLOptions struct {
+	Class string // Class for the <ul> element; defaults to "zvalidate".
+}
+
+func htmlOpen(opts []HTMLOptions) string {
+	class := "zvalidate"
+	if len(opts) > 0 && opts[0].Class != "" {
+		class = opts[0].Class
+	}
+	return fmt.Sprintf("<ul class='%s'>\n", template.HTMLEscapeString(class))
+}
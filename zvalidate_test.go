@@ -1,10 +1,12 @@
 package zvalidate
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"reflect"
+	"sync"
 	"testing"
 
 	"zgo.at/zstd/ztest"
@@ -28,7 +30,7 @@ func TestAs(t *testing.T) {
 	}
 
 	{ // Non-pointer
-		v := As(err)
+		v := As(vErr)
 		if v == nil {
 			t.Fatal("v is nil")
 		}
@@ -39,9 +41,51 @@ func TestAs(t *testing.T) {
 		}
 	}
 
+	{ // Wrapped
+		for _, wrapped := range []error{fmt.Errorf("wrap: %w", err), fmt.Errorf("wrap: %w", vErr)} {
+			v := As(wrapped)
+			if v == nil {
+				t.Fatal("v is nil")
+			}
+			if !v.HasKey("x") {
+				t.Errorf("wrong errors: %v", v.Errors)
+			}
+		}
+	}
+
 	if As(errors.New("X")) != nil {
 		t.Error("not nil")
 	}
+	if As(fmt.Errorf("wrap: %w", errors.New("X"))) != nil {
+		t.Error("not nil")
+	}
+	if As(nil) != nil {
+		t.Error("not nil")
+	}
+}
+
+func TestIs(t *testing.T) {
+	v := New()
+	v.Append("x", "y")
+
+	tests := []struct {
+		in   error
+		want bool
+	}{
+		{v.ErrorOrNil(), true},
+		{v, true},
+		{fmt.Errorf("wrap: %w", v.ErrorOrNil()), true},
+		{fmt.Errorf("wrap: %w", v), true},
+		{errors.New("x"), false},
+		{nil, false},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if out := errors.Is(tt.in, ErrValidation); out != tt.want {
+				t.Errorf("errors.Is(%#v): %t", tt.in, out)
+			}
+		})
+	}
 }
 
 func TestMerge(t *testing.T) {
@@ -91,6 +135,128 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeOnlyFirst(t *testing.T) {
+	v := New()
+	v.OnlyFirst()
+	v.Append("email", "err")
+
+	other := New()
+	other.Append("email", "err2")
+	other.Append("name", "err3")
+	other.Append("name", "err4")
+	v.Merge(other)
+	v.MergePrefix("billing", other)
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"email":         {"err"},
+		"name":          {"err3"},
+		"billing.email": {"err2"},
+		"billing.name":  {"err3"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Errorf(d)
+	}
+
+	want = fmt.Sprintf("%+v", []Field{
+		{Key: "email", Message: "err"},
+		{Key: "name", Message: "err3"},
+		{Key: "billing.email", Message: "err2"},
+		{Key: "billing.name", Message: "err3"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Fields()), want); d != "" {
+		t.Errorf(d)
+	}
+}
+
+func TestMergePrefix(t *testing.T) {
+	v := New()
+	v.Append("email", "err")
+
+	other := New()
+	other.Append("email", "err2")
+	other.Append("name", "err3")
+	v.MergePrefix("billing", other)
+	v.MergePrefix("shipping", other)
+	v.MergePrefix("empty", New())
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"email":          {"err"},
+		"billing.email":  {"err2"},
+		"billing.name":   {"err3"},
+		"shipping.email": {"err2"},
+		"shipping.name":  {"err3"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Errorf(d)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	v := New()
+	v.Prefix("empty")
+	if v.HasErrors() {
+		t.Fatal(v.Errors)
+	}
+
+	v.Append("email", "err")
+	v.AppendCode("name", "code", "err2")
+	v.Prefix("shipping")
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"shipping.email": {"err"},
+		"shipping.name":  {"err2"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Errorf(d)
+	}
+	if c := v.Codes["shipping.name"]; !reflect.DeepEqual(c, []string{"code"}) {
+		t.Errorf("codes: %#v", c)
+	}
+}
+
+func TestPrefixed(t *testing.T) {
+	v := New(WithMessages(Catalog{"required": "verplicht"}))
+	v.Required("name", "")
+
+	billing := v.Prefixed("billing")
+	billing.Required("city", "")
+	billing.Append("zip", "err")
+	billing.Warn("country", "warn")
+
+	addr := billing.Prefixed("address")
+	addr.Required("street", "")
+	sub := New()
+	sub.Append("x", "sub err")
+	addr.Sub("sub", "", sub)
+
+	v.Prefixed("shipping").When(true, func(v *Validator) {
+		v.Len("city", "x", 2, 0)
+	})
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"name":                   {"verplicht"},
+		"billing.city":           {"verplicht"},
+		"billing.zip":            {"err"},
+		"billing.address.street": {"verplicht"},
+		"billing.address.sub.x":  {"sub err"},
+		"shipping.city":          {"must be longer than 2 characters"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Warnings), fmt.Sprintf("%+v", map[string][]string{
+		"billing.country": {"warn"},
+	})); d != "" {
+		t.Error(d)
+	}
+	if c := v.Codes["billing.address.street"]; len(c) != 1 || c[0] != "required" {
+		t.Errorf("Codes: %#v", v.Codes)
+	}
+	if f := v.Fields(); len(f) != 6 || f[3].Key != "billing.address.street" {
+		t.Errorf("Fields: %#v", f)
+	}
+}
+
 func TestSub(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		v := New()
@@ -111,6 +277,15 @@ func TestSub(t *testing.T) {
 		addr2.Required("city", "")
 		v.Sub("addresses", "office", addr2)
 
+		// Index
+		addr3 := New()
+		addr3.Required("city", "")
+		v.SubIndex("addresses", 2, addr3)
+		v.SubIndex("addresses", 3, nil)
+		addr4 := New()
+		addr4.Required("city", "")
+		v.SubIndex("address", -1, addr4)
+
 		// Non-Validator.
 		v.Sub("other", "", errors.New("oh noes"))
 		v.Sub("emails", "home", nil)
@@ -137,6 +312,8 @@ func TestSub(t *testing.T) {
 			"setting.domain":           []string{"must be set"},
 			"setting.contactEmail":     []string{"must be a valid email address"},
 			"addresses[office].city":   []string{"must be set"},
+			"addresses[2].city":        []string{"must be set"},
+			"address.city":             []string{"must be set"},
 			"other":                    []string{"oh noes"},
 			"emails[office]":           []string{"not an email"},
 		})
@@ -147,24 +324,340 @@ func TestSub(t *testing.T) {
 	})
 }
 
+func TestAppendCode(t *testing.T) {
+	v := New()
+	v.Append("a", "err")
+	v.AppendCode("a", "code", "err %d", 2)
+	v.AppendCode("b", "code", "err3")
+
+	other := New()
+	other.Errors["a"] = []string{"no code"}
+	other.AppendCode("c", "code", "err4")
+	v.Merge(other)
+
+	sub := New()
+	sub.AppendCode("d", "code", "err5")
+	v.Sub("sub", "", sub)
+
+	wantErr := fmt.Sprintf("%+v", map[string][]string{
+		"a":     {"err", "err 2", "no code"},
+		"b":     {"err3"},
+		"c":     {"err4"},
+		"sub.d": {"err5"},
+	})
+	wantCodes := fmt.Sprintf("%+v", map[string][]string{
+		"a":     {"", "code", ""},
+		"b":     {"code"},
+		"c":     {"code"},
+		"sub.d": {"code"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), wantErr); d != "" {
+		t.Errorf(d)
+	}
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Codes), wantCodes); d != "" {
+		t.Errorf(d)
+	}
+
+	v.Pop("a")
+	if _, ok := v.Codes["a"]; ok {
+		t.Errorf("code still present after Pop(): %v", v.Codes)
+	}
+}
+
+func TestAppendVerbatim(t *testing.T) {
+	// Use variables, as go vet complains about constant strings.
+	off, full := "50% off", "100%"
+
+	v := New()
+	v.Append("a", off)
+	v.Append("a", "%d%% off", 50)
+	v.AppendCode("b", "code", full)
+	v.Warn("c", off)
+	v.Email("d", "x", off)
+	v.Len("e", "x", 2, 0, off)
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"a": {"50% off", "50% off"},
+		"b": {"100%"},
+		"d": {"50% off"},
+		"e": {"50% off"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Error(d)
+	}
+	if w := v.Warnings["c"]; len(w) != 1 || w[0] != "50% off" {
+		t.Errorf("Warnings: %#v", v.Warnings)
+	}
+}
+
+func TestAppendKey(t *testing.T) {
+	v := New()
+	v.AppendKey("items[0]", "50% off")
+	v.AppendKey("items[0]", "%s %d")
+
+	want := fmt.Sprintf("%+v", map[string][]string{"items[0]": {"50% off", "%s %d"}})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Error(d)
+	}
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Codes), fmt.Sprintf("%+v", map[string][]string{"items[0]": {"", ""}})); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestCodes(t *testing.T) {
+	v := New()
+	v.Required("email", "")
+	v.Email("email", "x")
+	v.Len("name", "x", 2, 0, "too short")
+	v.Append("other", "err")
+	v.Unique("list", []string{"a", "a"})
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"email":   {"required", "email"},
+		"name":    {"len_longer"},
+		"other":   {""},
+		"list[1]": {"unique"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Codes), want); d != "" {
+		t.Error(d)
+	}
+
+	j, err := v.ErrorJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"errors":{"email":["must be set","must be a valid email address"],` +
+		`"list[1]":["duplicate value ‘a’"],"name":["too short"],"other":["err"]},` +
+		`"codes":{"email":["required","email"],"list[1]":["unique"],"name":["len_longer"],"other":[""]}}`
+	if d := ztest.Diff(string(j), wantJSON); d != "" {
+		t.Error(d)
+	}
+
+	j, err = New().ErrorJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := ztest.Diff(string(j), `{"errors":{}}`); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestErrorJSONSorted(t *testing.T) {
+	v := New()
+	for _, k := range []string{"z", "b", "y", "a", "x", "c", "w", "d"} {
+		v.Append(k, "second")
+	}
+	v.Errors["a"] = []string{"first", "second"}
+
+	want := `{"errors":{"a":["first","second"],"b":["second"],"c":["second"],"d":["second"],` +
+		`"w":["second"],"x":["second"],"y":["second"],"z":["second"]},` +
+		`"codes":{"a":[""],"b":[""],"c":[""],"d":[""],"w":[""],"x":[""],"y":[""],"z":[""]}}`
+	for i := 0; i < 20; i++ {
+		j, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := ztest.Diff(string(j), want); d != "" {
+			t.Fatal(d)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	v := New()
+	v.Required("email", "")
+	v.Len("name", "x", 2, 10)
+	v.Include("role", "x", []string{"admin", "user"})
+	v.Range("age", 200, 18, 150, "too old")
+	v.Append("other", "err")
+	v.Append("pop", "err")
+	v.Pop("pop")
+
+	sub := New()
+	sub.Required("city", "")
+	v.Sub("address", "", sub)
+
+	want := []Field{
+		{Key: "email", Rule: "required", Message: "must be set"},
+		{Key: "name", Rule: "len_longer", Params: map[string]interface{}{"min": 2}, Message: "must be longer than 2 characters"},
+		{Key: "role", Rule: "include", Params: map[string]interface{}{"values": []string{"admin", "user"}}, Message: "must be one of ‘admin, user’"},
+		{Key: "age", Rule: "range_lower", Params: map[string]interface{}{"max": int64(150)}, Message: "too old"},
+		{Key: "other", Rule: "", Message: "err"},
+		{Key: "address.city", Rule: "required", Message: "must be set"},
+	}
+	if out := v.Fields(); !reflect.DeepEqual(out, want) {
+		t.Errorf("\nout:  %#v\nwant: %#v\n", out, want)
+	}
+
+	j, err := json.Marshal(v.Fields()[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `[{"key":"email","rule":"required","message":"must be set"},` +
+		`{"key":"name","rule":"len_longer","params":{"min":2},"message":"must be longer than 2 characters"},` +
+		`{"key":"role","rule":"include","params":{"values":["admin","user"]},"message":"must be one of ‘admin, user’"},` +
+		`{"key":"age","rule":"range_lower","params":{"max":150},"message":"too old"}]`
+	if d := ztest.Diff(string(j), wantJSON); d != "" {
+		t.Error(d)
+	}
+
+	v.Prefix("form")
+	if k := v.Fields()[0].Key; k != "form.email" {
+		t.Errorf("key after Prefix(): %q", k)
+	}
+}
+
+func TestEach(t *testing.T) {
+	v := New()
+	v.Each("none", nil, func(v *Validator, key, value string) { t.Error("called") })
+	v.Each("emails", []string{"a@example.com", "x", "", "y"}, func(v *Validator, key, value string) {
+		v.Email(key, value)
+	})
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"emails[1]": {"must be a valid email address"},
+		"emails[3]": {"must be a valid email address"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Errorf(d)
+	}
+}
+
+func TestCSV(t *testing.T) {
+	v := New()
+	fn := func(v *Validator, key, token string) {
+		v.Required(key, token)
+		v.Len(key, token, 0, 3)
+	}
+
+	if out := v.CSV("none", "", 0, fn); out != nil {
+		t.Errorf("out: %#v", out)
+	}
+	out := v.CSV("tags", "a, bb ,,long", 0, fn)
+	if want := []string{"a", "bb", "", "long"}; !reflect.DeepEqual(out, want) {
+		t.Errorf("\nout:  %#v\nwant: %#v\n", out, want)
+	}
+	if out := v.CSV("max", "a,b,c", 2, fn); out != nil {
+		t.Errorf("out: %#v", out)
+	}
+	v.CSV("msg", "a,b,c", 2, fn, "foo")
+	v.CSV("ok", "a,b", 2, fn)
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"tags[2]": {"must be set"},
+		"tags[3]": {"must be shorter than 3 characters"},
+		"max":     {"must have at most 2 items"},
+		"msg":     {"foo"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Errorf(d)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	v := NewConcurrent()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v.Append("key", "err")
+			v.Required(fmt.Sprintf("key%d", i%5), "")
+
+			other := New()
+			other.Append("other", "err")
+			v.Merge(other)
+			v.Sub("sub", "", other)
+		}(i)
+	}
+	wg.Wait()
+
+	if n := len(v.Errors["key"]); n != 50 {
+		t.Errorf("len(key): %d", n)
+	}
+	if n := len(v.Errors["key3"]); n != 10 {
+		t.Errorf("len(key3): %d", n)
+	}
+	if n := len(v.Errors["other"]); n != 50 {
+		t.Errorf("len(other): %d", n)
+	}
+	if n := len(v.Errors["sub.other"]); n != 50 {
+		t.Errorf("len(sub.other): %d", n)
+	}
+}
+
+func TestWhen(t *testing.T) {
+	v := New()
+	v.When(false, func(v *Validator) { v.Required("no", "") })
+	v.When(true, func(v *Validator) { v.Required("yes", "") })
+
+	want := fmt.Sprintf("%+v", map[string][]string{"yes": {"must be set"}})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Errorf(d)
+	}
+}
+
+func TestIf(t *testing.T) {
+	v := New()
+	v.If(true).Required("a", "")
+	v.If(false).Required("b", "")
+	v.Unless(false).Email("c", "x")
+	v.Unless(true).Email("d", "x")
+	v.If(false).Append("e", "err")
+	v.If(false).Warn("f", "warn")
+	v.If(false).Sub("g", "", errors.New("err"))
+	v.If(true).Prefixed("h").Required("i", "")
+	v.Prefixed("j").If(false).Required("k", "")
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"a":   {"must be set"},
+		"c":   {"must be a valid email address"},
+		"h.i": {"must be set"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Error(d)
+	}
+	if v.HasWarnings() {
+		t.Errorf("warnings: %v", v.Warnings)
+	}
+}
+
+func TestOnlyFirst(t *testing.T) {
+	v := New()
+	v.OnlyFirst()
+	v.Required("email", "")
+	v.Email("email", "not an email")
+	v.Email("email2", "not an email")
+	v.Len("email2", "not an email", 20, 0)
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"email":  {"must be set"},
+		"email2": {"must be a valid email address"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), want); d != "" {
+		t.Errorf(d)
+	}
+}
+
 func TestString(t *testing.T) {
 	tests := []struct {
 		in   Validator
 		want string
 	}{
 		{Validator{}, ""},
-		{Validator{map[string][]string{}}, ""},
+		{Validator{Errors: map[string][]string{}}, ""},
 
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no"},
 		}}, "k: oh no.\n"},
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more"},
 		}}, "k: oh no, more.\n"},
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more", "even more"},
 		}}, "k: oh no, more, even more.\n"},
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k":  {"oh no", "more", "even more"},
 			"k2": {"asd"},
 		}}, "k: oh no, more, even more.\nk2: asd.\n"},
@@ -180,6 +673,35 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		in         Validator
+		sep, kvSep string
+		want       string
+	}{
+		{Validator{}, "; ", ": ", ""},
+		{Validator{Errors: map[string][]string{"k": {"oh no"}}}, "; ", ": ", "k: oh no"},
+		{Validator{Errors: map[string][]string{
+			"k":  {"oh no", "more"},
+			"k2": {"asd"},
+			"":   {"no key"},
+		}}, "; ", ": ", "no key; k: oh no, more; k2: asd"},
+		{Validator{Errors: map[string][]string{
+			"k":  {"oh no"},
+			"k2": {"asd"},
+		}}, " | ", "=", "k=oh no | k2=asd"},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%v", i), func(t *testing.T) {
+			out := tt.in.Format(tt.sep, tt.kvSep)
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+}
+
 func BenchmarkString(b *testing.B) {
 	v := New()
 	noOfErrors := 256
@@ -202,21 +724,25 @@ func TestHTML(t *testing.T) {
 		want template.HTML
 	}{
 		{Validator{}, ""},
-		{Validator{map[string][]string{}}, ""},
+		{Validator{Errors: map[string][]string{}}, ""},
 
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no"},
 		}}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no.</li>\n</ul>\n"},
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more"},
 		}}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more.</li>\n</ul>\n"},
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k": {"oh no", "more", "even more"},
 		}}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more, even more.</li>\n</ul>\n"},
-		{Validator{map[string][]string{
+		{Validator{Errors: map[string][]string{
 			"k":  {"oh no", "more", "even more"},
 			"k2": {"asd"},
 		}}, "<ul class='zvalidate'>\n<li><strong>k</strong>: oh no, more, even more.</li>\n<li><strong>k2</strong>: asd.</li>\n</ul>\n"},
+		{Validator{Errors: map[string][]string{
+			"<k>":   {`must be one of ‘<b>, "a&b"’`},
+			"empty": {},
+		}}, "<ul class='zvalidate'>\n<li><strong>&lt;k&gt;</strong>: must be one of ‘&lt;b&gt;, &#34;a&amp;b&#34;’.</li>\n</ul>\n"},
 	}
 
 	for i, tt := range tests {
@@ -229,6 +755,38 @@ func TestHTML(t *testing.T) {
 	}
 }
 
+func TestHTMLFor(t *testing.T) {
+	v := New()
+	v.Append("k", "oh no")
+	v.Append("k", "<b>more</b>")
+	v.Append("k2", "asd")
+
+	tests := []struct {
+		key  string
+		opts []HTMLOptions
+		want template.HTML
+	}{
+		{"k", nil, "<ul class='zvalidate'>\n<li>oh no.</li>\n<li>&lt;b&gt;more&lt;/b&gt;.</li>\n</ul>\n"},
+		{"k2", []HTMLOptions{{Class: "errors"}}, "<ul class='errors'>\n<li>asd.</li>\n</ul>\n"},
+		{"k2", []HTMLOptions{{Class: "x' onclick='y"}}, "<ul class='x&#39; onclick=&#39;y'>\n<li>asd.</li>\n</ul>\n"},
+		{"k3", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			out := v.HTMLFor(tt.key, tt.opts...)
+			if out != tt.want {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+		})
+	}
+
+	out := v.HTML(HTMLOptions{Class: "errors"})
+	want := template.HTML("<ul class='errors'>\n<li><strong>k</strong>: oh no, &lt;b&gt;more&lt;/b&gt;.</li>\n<li><strong>k2</strong>: asd.</li>\n</ul>\n")
+	if out != want {
+		t.Errorf("\nout:  %#v\nwant: %#v\n", out, want)
+	}
+}
+
 func TestErrorOrNil(t *testing.T) {
 	tests := []struct {
 		in   *Validator
@@ -314,3 +872,235 @@ func TestPop(t *testing.T) {
 		t.Errorf("v.HasErrors(): %#v", v.Errors)
 	}
 }
+
+func TestDelete(t *testing.T) {
+	v := New()
+	v.Append("a", "err")
+	v.AppendCode("a", "code", "err2")
+	v.Append("b", "err3")
+
+	v.Delete("nonexistent")
+	v.Delete("a")
+	wantErr := fmt.Sprintf("%+v", map[string][]string{"b": {"err3"}})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Errors), wantErr); d != "" {
+		t.Error(d)
+	}
+	if _, ok := v.Codes["a"]; ok {
+		t.Errorf("Codes: %#v", v.Codes)
+	}
+	if f := v.Fields(); len(f) != 1 || f[0].Key != "b" {
+		t.Errorf("Fields: %#v", f)
+	}
+
+	v.Delete("b")
+	if v.HasErrors() {
+		t.Errorf("v.HasErrors(): %#v", v.Errors)
+	}
+	if err := v.ErrorOrNil(); err != nil {
+		t.Errorf("v.ErrorOrNil(): %#v", err)
+	}
+}
+
+func TestHasErrorsEmpty(t *testing.T) {
+	v := New()
+	v.Errors["a"] = []string{}
+	v.Errors["b"] = nil
+	if v.HasErrors() {
+		t.Errorf("v.HasErrors() with empty slices: %#v", v.Errors)
+	}
+
+	if out := v.Pop("a"); out != nil {
+		t.Errorf("Pop() on empty slice: %#v", out)
+	}
+	if _, ok := v.Errors["a"]; ok {
+		t.Error("empty slice not removed by Pop()")
+	}
+
+	v.Append("c", "err")
+	if !v.HasErrors() {
+		t.Errorf("v.HasErrors() false: %#v", v.Errors)
+	}
+}
+
+func TestErrorsFor(t *testing.T) {
+	v := New()
+	v.Append("a", "err")
+	v.Append("a", "err2")
+
+	tests := []struct {
+		key    string
+		want   []string
+		joined string
+	}{
+		{"a", []string{"err", "err2"}, "err, err2"},
+		{"b", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			out := v.ErrorsFor(tt.key)
+			if !reflect.DeepEqual(out, tt.want) {
+				t.Errorf("\nout:  %#v\nwant: %#v\n", out, tt.want)
+			}
+			if j := v.JoinedFor(tt.key, ", "); j != tt.joined {
+				t.Errorf("JoinedFor: %q; want %q", j, tt.joined)
+			}
+		})
+	}
+
+	// Returns a copy.
+	v.ErrorsFor("a")[0] = "modified"
+	if v.Errors["a"][0] != "err" {
+		t.Errorf("Errors modified: %#v", v.Errors)
+	}
+}
+
+func TestFirst(t *testing.T) {
+	v := New()
+	if k, m := v.First(); k != "" || m != "" {
+		t.Errorf("First() on empty: %q, %q", k, m)
+	}
+	if m := v.FirstError(); m != "" {
+		t.Errorf("FirstError() on empty: %q", m)
+	}
+
+	v.Errors["a"] = []string{}
+	v.Append("c", "err3")
+	v.Append("b", "err1")
+	v.Append("b", "err2")
+	for i := 0; i < 10; i++ {
+		if k, m := v.First(); k != "b" || m != "err1" {
+			t.Fatalf("First(): %q, %q", k, m)
+		}
+		if m := v.FirstError(); m != "err1" {
+			t.Fatalf("FirstError(): %q", m)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	v := New()
+	if n := v.Count(); n != 0 {
+		t.Errorf("Count: %d", n)
+	}
+
+	v.Append("a", "err")
+	v.Append("a", "err2")
+	v.Append("b", "err3")
+	v.Errors["empty"] = []string{}
+	if n := v.Count(); n != 3 {
+		t.Errorf("Count: %d", n)
+	}
+}
+
+func TestWarn(t *testing.T) {
+	v := New()
+	v.Warn("a", "warn %d", 1)
+	v.Soft(func(v *Validator) {
+		v.Required("b", "")
+		v.Len("c", "xxx", 0, 2)
+		v.Email("d", "martin@example.com")
+	})
+	if v.HasErrors() || v.ErrorOrNil() != nil {
+		t.Errorf("v.HasErrors(): %#v", v.Errors)
+	}
+	if !v.HasWarnings() {
+		t.Error("v.HasWarnings() false")
+	}
+
+	sub := New()
+	sub.Warn("e", "sub warn")
+	v.Sub("sub", "", sub)
+	v.Prefix("p")
+
+	want := fmt.Sprintf("%+v", map[string][]string{
+		"p.a":     {"warn 1"},
+		"p.b":     {"must be set"},
+		"p.c":     {"must be shorter than 2 characters"},
+		"p.sub.e": {"sub warn"},
+	})
+	if d := ztest.Diff(fmt.Sprintf("%+v", v.Warnings), want); d != "" {
+		t.Error(d)
+	}
+
+	v = New()
+	v.Warn("a", "w")
+	v.Append("b", "e")
+	j, err := v.ErrorJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"errors":{"b":["e"]},"codes":{"b":[""]},"warnings":{"a":["w"]}}`
+	if d := ztest.Diff(string(j), wantJSON); d != "" {
+		t.Error(d)
+	}
+}
+
+func TestHasErrorsUnder(t *testing.T) {
+	v := New()
+	v.Append("name", "err")
+	v.Append("settingsx", "err")
+	v.Errors["empty"] = []string{}
+	v.Errors["empty.x"] = nil
+
+	sub := New()
+	sub.Append("domain", "err")
+	v.Sub("settings", "", sub)
+	v.SubIndex("addresses", 1, sub)
+	v.Each("emails", []string{"", "x"}, func(v *Validator, key, value string) {
+		v.Required(key, value)
+	})
+
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"name", true},
+		{"nam", false},
+		{"settings", true},
+		{"settings.domain", true},
+		{"settings.dom", false},
+		{"addresses", true},
+		{"addresses[1]", true},
+		{"addresses[0]", false},
+		{"emails", true},
+		{"emails[0]", true},
+		{"emails[1]", false},
+		{"empty", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if out := v.HasErrorsUnder(tt.in); out != tt.want {
+				t.Errorf("HasErrorsUnder(%q): %t", tt.in, out)
+			}
+		})
+	}
+
+	if !v.HasErrorsFor("name") || v.HasErrorsFor("settings") {
+		t.Error("HasErrorsFor")
+	}
+}
+
+func TestHasKey(t *testing.T) {
+	v := New()
+	if v.HasKey("a") {
+		t.Error("HasKey true for empty validator")
+	}
+	if k := v.Keys(); len(k) != 0 {
+		t.Errorf("Keys: %#v", k)
+	}
+
+	v.Append("b", "err")
+	v.Append("a", "err")
+	v.Errors["empty"] = []string{}
+
+	if !v.HasKey("a") || !v.HasKey("b") {
+		t.Error("HasKey false")
+	}
+	if v.HasKey("c") || v.HasKey("empty") {
+		t.Error("HasKey true")
+	}
+	if k, want := v.Keys(), []string{"a", "b"}; !reflect.DeepEqual(k, want) {
+		t.Errorf("\nout:  %#v\nwant: %#v\n", k, want)
+	}
+}